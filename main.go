@@ -5,159 +5,583 @@ Description:
 This is a simple receipt processing web service implemented in Go.
 It allows users to submit receipts, calculates reward points based on predefined rules,
 and retrieves the points awarded for a given receipt.
-The application runs as a REST API and stores data in memory.
+The application runs as a REST API, persisting data through a pluggable Store.
 */
 
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"math"
 	"net/http"
-	"regexp"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/nwc6624/receipt-processor-challenge/ocr"
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+	"github.com/nwc6624/receipt-processor-challenge/store"
+	"github.com/nwc6624/receipt-processor-challenge/uploads"
 )
 
-// Receipt represents the structure of a receipt submitted by the user.
-type Receipt struct {
-	Retailer     string `json:"retailer"`
-	PurchaseDate string `json:"purchaseDate"`
-	PurchaseTime string `json:"purchaseTime"`
-	Total        string `json:"total"`
-	Items        []Item `json:"items"`
-}
+// Receipt represents the structure of a receipt submitted by the user. It is
+// an alias for rules.Receipt so the scoring engine and the HTTP layer always
+// agree on shape without a conversion step.
+type Receipt = rules.Receipt
 
 // Item represents an individual item on a receipt.
-type Item struct {
-	ShortDescription string `json:"shortDescription"`
-	Price            string `json:"price"`
+type Item = rules.Item
+
+// Environment variables that configure the scoring rules, the store, and the
+// upload pipeline, read once at startup.
+const (
+	rulesConfigPathEnv = "RULES_CONFIG_PATH"
+	storeBackendEnv    = "STORE_BACKEND"
+	storeDSNEnv        = "STORE_DSN"
+	readTimeoutEnv     = "READ_TIMEOUT"
+	writeTimeoutEnv    = "WRITE_TIMEOUT"
+	uploadBlobDirEnv   = "UPLOAD_BLOB_DIR"
+	uploadWorkersEnv   = "UPLOAD_WORKERS"
+	uploadQueueEnv     = "UPLOAD_QUEUE_DEPTH"
+	uploadOCRTimeout   = "UPLOAD_OCR_TIMEOUT"
+	ocrBackendEnv      = "OCR_BACKEND"
+	ocrRemoteURLEnv    = "OCR_REMOTE_URL"
+)
+
+// Defaults used when the corresponding environment variable above is unset.
+const (
+	defaultRulesConfigPath  = "rules.json"
+	defaultReadTimeout      = 5 * time.Second
+	defaultWriteTimeout     = 10 * time.Second
+	defaultUploadBlobDir    = "uploads"
+	defaultUploadWorkers    = 4
+	defaultUploadQueueDepth = 64
+	defaultUploadOCRTimeout = 30 * time.Second
+)
+
+// loadScoringEngine loads the rules config from the path named by
+// rulesConfigPathEnv (or defaultRulesConfigPath if unset) and builds the
+// Engine that ProcessReceipt and GetPoints use to score receipts.
+func loadScoringEngine() (*rules.Engine, error) {
+	path := os.Getenv(rulesConfigPathEnv)
+	if path == "" {
+		path = defaultRulesConfigPath
+	}
+	cfg, err := rules.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.BuildEngine()
 }
 
-// In-memory storage for receipt IDs and their corresponding points.
-var receipts = make(map[string]int)
+// loadStoreConfig builds a store.Config from the environment, defaulting to
+// an in-memory backend with conservative HTTP timeouts.
+func loadStoreConfig() store.Config {
+	backend := os.Getenv(storeBackendEnv)
+	if backend == "" {
+		backend = store.BackendMemory
+	}
+	cfg := store.Config{
+		Backend:      backend,
+		DSN:          os.Getenv(storeDSNEnv),
+		ReadTimeout:  defaultReadTimeout,
+		WriteTimeout: defaultWriteTimeout,
+	}
+	if v := os.Getenv(readTimeoutEnv); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.ReadTimeout = d
+		}
+	}
+	if v := os.Getenv(writeTimeoutEnv); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.WriteTimeout = d
+		}
+	}
+	return cfg
+}
 
-// validateReceipt ensures that the receipt structure is correct and contains valid data.
-func validateReceipt(receipt Receipt) error {
-	if receipt.Retailer == "" || receipt.PurchaseDate == "" || receipt.PurchaseTime == "" || receipt.Total == "" || len(receipt.Items) == 0 {
-		return fmt.Errorf("The receipt is invalid.") // Matches OpenAPI error response
+// loadUploadConfig builds an uploads.Config from the environment, defaulting
+// to a local "uploads" blob directory with a modest worker pool.
+func loadUploadConfig() uploads.Config {
+	cfg := uploads.Config{
+		BlobDir:     os.Getenv(uploadBlobDirEnv),
+		WorkerCount: defaultUploadWorkers,
+		QueueDepth:  defaultUploadQueueDepth,
+		OCRTimeout:  defaultUploadOCRTimeout,
 	}
-	if !regexp.MustCompile(`^[\w\s\-&]+$`).MatchString(receipt.Retailer) {
-		return fmt.Errorf("The receipt is invalid: retailer name format is incorrect.")
+	if cfg.BlobDir == "" {
+		cfg.BlobDir = defaultUploadBlobDir
 	}
-	if _, err := time.Parse("2006-01-02", receipt.PurchaseDate); err != nil {
-		return fmt.Errorf("The receipt is invalid: purchaseDate format must be YYYY-MM-DD.")
+	if v := os.Getenv(uploadWorkersEnv); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.WorkerCount = n
+		}
 	}
-	if _, err := time.Parse("15:04", receipt.PurchaseTime); err != nil {
-		return fmt.Errorf("The receipt is invalid: purchaseTime format must be HH:MM (24-hour format).")
+	if v := os.Getenv(uploadQueueEnv); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.QueueDepth = n
+		}
 	}
-	if !regexp.MustCompile(`^\d+\.\d{2}$`).MatchString(receipt.Total) {
-		return fmt.Errorf("The receipt is invalid: total format must be a decimal with two places.")
+	if v := os.Getenv(uploadOCRTimeout); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.OCRTimeout = d
+		}
 	}
-	return nil
+	return cfg
 }
 
-// calculatePoints applies predefined rules to determine the number of points a receipt earns.
-func calculatePoints(receipt Receipt) int {
-	points := 0
-	alphanumericRegex := regexp.MustCompile("[a-zA-Z0-9]")
+// loadOCRBackend selects the OCR implementation named by ocrBackendEnv.
+// Defaults to TesseractOCR, since it requires no extra configuration beyond
+// having the tesseract binary on PATH.
+func loadOCRBackend() ocr.OCR {
+	switch os.Getenv(ocrBackendEnv) {
+	case "remote":
+		return ocr.RemoteOCR{Endpoint: os.Getenv(ocrRemoteURLEnv)}
+	default:
+		return ocr.TesseractOCR{}
+	}
+}
 
-	// Rule 1: One point per alphanumeric character in the retailer name.
-	retailerPoints := len(alphanumericRegex.FindAllString(receipt.Retailer, -1))
-	points += retailerPoints
+// Valid values a receipt's status can be flagged with during triage.
+const (
+	StatusOpen           = store.StatusOpen
+	StatusResolved       = store.StatusResolved
+	StatusNeedsAttention = store.StatusNeedsAttention
+)
 
-	total, _ := strconv.ParseFloat(receipt.Total, 64)
+var validStatuses = map[string]bool{
+	StatusOpen:           true,
+	StatusResolved:       true,
+	StatusNeedsAttention: true,
+}
 
-	// Rule 2: 50 points if the total is a round dollar amount with no cents.
-	if math.Mod(total, 1) == 0 {
-		points += 50
-	}
+// server holds the dependencies every HTTP handler needs, replacing the
+// package-level globals the original implementation used. This is also what
+// makes the handlers unit-testable: tests construct a server around a fresh
+// store.MemoryStore instead of sharing mutable package state.
+type server struct {
+	store   store.Store
+	engine  *rules.Engine
+	uploads *uploads.Manager
+}
 
-	// Rule 3: 25 points if the total is a multiple of 0.25.
-	if math.Mod(total, 0.25) == 0 {
-		points += 25
-	}
+// ReceiptFilter holds the optional server-side filter predicates accepted by
+// GET /receipts.
+type ReceiptFilter = store.Filter
+
+// ReceiptPagedRequestCommand models a GET /receipts request. Call
+// LoadDataFromRequest to decode and validate the query string in one place,
+// the same command pattern Receipt Wrangler uses for its paged endpoints.
+type ReceiptPagedRequestCommand struct {
+	Page          int
+	PageSize      int
+	OrderBy       string
+	SortDirection string
+	Filter        ReceiptFilter
+}
 
-	// Rule 4: 5 points for every two items on the receipt.
-	itemPairsPoints := (len(receipt.Items) / 2) * 5
-	points += itemPairsPoints
+// maxPage bounds the "page" query param so that (page-1)*pageSize can never
+// overflow int: with pageSize capped at 100, this still allows paging through
+// a hundred million receipts, far beyond any real deployment of this service.
+const maxPage = 1_000_000
+
+// validOrderByFields lists the StoredReceipt fields that GET /receipts may
+// sort on.
+var validOrderByFields = map[string]bool{
+	"submittedAt": true,
+	"total":       true,
+	"points":      true,
+	"retailer":    true,
+}
 
-	// Rule 5: Additional points if item description length is a multiple of 3.
-	for _, item := range receipt.Items {
-		desc := strings.TrimSpace(item.ShortDescription)
-		price, _ := strconv.ParseFloat(item.Price, 64)
-		descLen := len(desc)
+// LoadDataFromRequest decodes the query parameters of r into c and validates
+// them, returning an error describing the first problem found.
+func (c *ReceiptPagedRequestCommand) LoadDataFromRequest(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
 
-		if descLen%3 == 0 {
-			extraPoints := int(math.Ceil(price*0.2 + 0.0001)) // Fix rounding issue
-			points += extraPoints
+	c.Page = 1
+	if v := q.Get("page"); v != "" {
+		page, err := strconv.Atoi(v)
+		if err != nil || page < 1 || page > maxPage {
+			return fmt.Errorf("page must be a positive integer no greater than %d", maxPage)
 		}
+		c.Page = page
 	}
 
-	// Rule 6: 6 points if the purchase day is odd.
-	dateParts := strings.Split(receipt.PurchaseDate, "-")
-	if len(dateParts) == 3 {
-		day, _ := strconv.Atoi(dateParts[2])
-		if day%2 == 1 {
-			points += 6
+	c.PageSize = 20
+	if v := q.Get("pageSize"); v != "" {
+		pageSize, err := strconv.Atoi(v)
+		if err != nil || pageSize < 1 || pageSize > 100 {
+			return fmt.Errorf("pageSize must be an integer between 1 and 100")
 		}
+		c.PageSize = pageSize
+	}
+
+	c.OrderBy = "submittedAt"
+	if v := q.Get("orderBy"); v != "" {
+		if !validOrderByFields[v] {
+			return fmt.Errorf("orderBy must be one of submittedAt, total, points, retailer")
+		}
+		c.OrderBy = v
+	}
+
+	c.SortDirection = "asc"
+	if v := q.Get("sortDirection"); v != "" {
+		v = strings.ToLower(v)
+		if v != "asc" && v != "desc" {
+			return fmt.Errorf("sortDirection must be asc or desc")
+		}
+		c.SortDirection = v
+	}
+
+	c.Filter.Retailer = q.Get("retailer")
+	c.Filter.PurchaseDateFrom = q.Get("purchaseDateFrom")
+	c.Filter.PurchaseDateTo = q.Get("purchaseDateTo")
+
+	var err error
+	if c.Filter.MinTotal, err = parseOptionalFloat(q.Get("minTotal")); err != nil {
+		return fmt.Errorf("minTotal must be a number")
+	}
+	if c.Filter.MaxTotal, err = parseOptionalFloat(q.Get("maxTotal")); err != nil {
+		return fmt.Errorf("maxTotal must be a number")
 	}
+	if c.Filter.MinPoints, err = parseOptionalInt(q.Get("minPoints")); err != nil {
+		return fmt.Errorf("minPoints must be an integer")
+	}
+	if c.Filter.MaxPoints, err = parseOptionalInt(q.Get("maxPoints")); err != nil {
+		return fmt.Errorf("maxPoints must be an integer")
+	}
+
+	return nil
+}
 
-	// Rule 7: 10 points if the purchase time is between 2:00 PM and 4:00 PM.
-	t, _ := time.Parse("15:04", receipt.PurchaseTime)
-	if t.Hour() >= 14 && t.Hour() < 16 {
-		points += 10
+// toStorePage converts cmd's pagination/sort fields into a store.Page.
+func (c *ReceiptPagedRequestCommand) toStorePage() store.Page {
+	return store.Page{
+		Number:        c.Page,
+		Size:          c.PageSize,
+		OrderBy:       c.OrderBy,
+		SortDirection: c.SortDirection,
 	}
+}
 
-	// Rule 8: 5 points if the total is greater than 10.00.
-	if total > 10.00 {
-		points += 5
+// parseOptionalFloat parses s as a float64, returning a nil pointer for an
+// empty string.
+func parseOptionalFloat(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// parseOptionalInt parses s as an int, returning a nil pointer for an empty
+// string.
+func parseOptionalInt(s string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, err
 	}
+	return &v, nil
+}
+
+// BulkStatusRequest is the request body for POST /receipts/bulk-status.
+type BulkStatusRequest struct {
+	ReceiptIDs []string `json:"receiptIds"`
+	Status     string   `json:"status"`
+	Comment    string   `json:"comment"`
+}
 
-	return points
+// BulkStatusResponse reports the outcome of a bulk status update.
+type BulkStatusResponse struct {
+	Updated  []string `json:"updated"`
+	NotFound []string `json:"notFound"`
+}
+
+// validateReceipt ensures that the receipt structure is correct and contains valid data.
+func validateReceipt(receipt Receipt) error {
+	return rules.ValidateReceipt(receipt)
 }
 
 // ProcessReceipt handles POST /receipts/process - Stores receipt and calculates points.
-func ProcessReceipt(w http.ResponseWriter, r *http.Request) {
+//
+// Submitting the same receipt content twice, or replaying a request that
+// carries the same Idempotency-Key header, returns the originally created ID
+// instead of minting a new one and double-counting points.
+func (s *server) ProcessReceipt(w http.ResponseWriter, r *http.Request) {
 	var receipt Receipt
 	err := json.NewDecoder(r.Body).Decode(&receipt)
 	if err != nil || validateReceipt(receipt) != nil {
 		http.Error(w, "The receipt is invalid.", http.StatusBadRequest) // Matches OpenAPI
 		return
 	}
-	receiptID := uuid.New().String()
-	receipts[receiptID] = calculatePoints(receipt)
-	response := map[string]string{"id": receiptID}
+
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+
+	if idempotencyKey != "" {
+		if existingID, ok, err := s.store.FindByIdempotencyKey(idempotencyKey); err != nil {
+			http.Error(w, "Failed to look up idempotency key.", http.StatusInternalServerError)
+			return
+		} else if ok {
+			writeJSONStatus(w, http.StatusOK, map[string]string{"id": existingID})
+			return
+		}
+	}
+
+	points, explanation := s.engine.Explain(receipt)
+	receiptID, created, err := s.store.Save(uuid.New().String(), receipt, points, explanation)
+	if err != nil {
+		http.Error(w, "Failed to store receipt.", http.StatusInternalServerError)
+		return
+	}
+
+	if idempotencyKey != "" {
+		if err := s.store.LinkIdempotencyKey(idempotencyKey, receiptID); err != nil {
+			http.Error(w, "Failed to store idempotency key.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if !created {
+		writeJSONStatus(w, http.StatusOK, map[string]string{"id": receiptID})
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(map[string]string{"id": receiptID})
 }
 
-// GetPoints handles GET /receipts/{id}/points - Retrieves points for a given receipt ID.
-func GetPoints(w http.ResponseWriter, r *http.Request) {
+// writeJSONStatus writes body as JSON with an explicit status code.
+func writeJSONStatus(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// PointsExplainResponse is the response body for
+// GET /receipts/{id}/points?explain=true.
+type PointsExplainResponse struct {
+	Points      int                 `json:"points"`
+	Explanation []rules.Explanation `json:"explanation"`
+}
+
+// GetPoints handles GET /receipts/{id}/points - Retrieves points for a given
+// receipt ID. With ?explain=true, it also returns a breakdown of which rules
+// fired and how many points each contributed.
+func (s *server) GetPoints(w http.ResponseWriter, r *http.Request) {
 	receiptID := strings.TrimPrefix(r.URL.Path, "/receipts/")
 	receiptID = strings.TrimSuffix(receiptID, "/points")
-	points, exists := receipts[receiptID]
+
+	sr, exists, err := s.store.Get(receiptID)
+	if err != nil {
+		http.Error(w, "Failed to look up receipt.", http.StatusInternalServerError)
+		return
+	}
 	if !exists {
 		http.Error(w, "No receipt found for that ID.", http.StatusNotFound) // Matches OpenAPI
 		return
 	}
-	response := map[string]int{"points": points}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Query().Get("explain") == "true" {
+		// Replay the breakdown recorded at submission time rather than
+		// recomputing it against whatever rules.json says now: rules are
+		// config-driven specifically so scoring can be promoted or A/B
+		// tested with a config change and restart, and recomputing here
+		// would make this endpoint silently disagree with sr.Points (and
+		// with the plain, non-explain response below) the moment that
+		// config changes.
+		json.NewEncoder(w).Encode(PointsExplainResponse{Points: sr.Points, Explanation: sr.Explanation})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]int{"points": sr.Points})
+}
+
+// ListReceiptsResponse is the response body for GET /receipts.
+type ListReceiptsResponse struct {
+	Receipts   []store.StoredReceipt `json:"receipts"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"pageSize"`
+	TotalCount int                   `json:"totalCount"`
+}
+
+// ListReceipts handles GET /receipts - Returns a paged, filtered, sorted list
+// of stored receipts along with their computed points.
+func (s *server) ListReceipts(w http.ResponseWriter, r *http.Request) {
+	var cmd ReceiptPagedRequestCommand
+	if err := cmd.LoadDataFromRequest(w, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, total, err := s.store.List(cmd.Filter, cmd.toStorePage())
+	if err != nil {
+		http.Error(w, "Failed to list receipts.", http.StatusInternalServerError)
+		return
+	}
+
+	response := ListReceiptsResponse{
+		Receipts:   page,
+		Page:       cmd.Page,
+		PageSize:   cmd.PageSize,
+		TotalCount: total,
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// BulkUpdateStatus handles POST /receipts/bulk-status - Flags a batch of
+// receipts with a triage status in a single round trip.
+func (s *server) BulkUpdateStatus(w http.ResponseWriter, r *http.Request) {
+	var req BulkStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "The request body is invalid.", http.StatusBadRequest)
+		return
+	}
+	if !validStatuses[req.Status] {
+		http.Error(w, "status must be one of Open, Resolved, NeedsAttention.", http.StatusBadRequest)
+		return
+	}
+	if len(req.ReceiptIDs) == 0 {
+		http.Error(w, "receiptIds must not be empty.", http.StatusBadRequest)
+		return
+	}
+
+	updated, notFound, err := s.store.BulkUpdateStatus(req.ReceiptIDs, req.Status, req.Comment)
+	if err != nil {
+		http.Error(w, "Failed to update receipts.", http.StatusInternalServerError)
+		return
+	}
+
+	response := BulkStatusResponse{Updated: updated, NotFound: notFound}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// maxUploadBytes bounds how large a single multipart upload may be, so a
+// malicious or mistaken client can't exhaust the blob directory in one request.
+const maxUploadBytes = 20 << 20 // 20 MiB
+
+// UploadReceiptResponse is the response body for POST /receipts/upload.
+type UploadReceiptResponse struct {
+	JobIDs []string `json:"jobIds"`
+}
+
+// UploadReceipt handles POST /receipts/upload - Accepts one or more scanned
+// receipt images or PDFs, persists them, and queues each for asynchronous OCR
+// extraction and scoring. Callers poll GET /receipts/uploads/{jobId} for the
+// outcome.
+func (s *server) UploadReceipt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes)
+	if err := r.ParseMultipartForm(maxUploadBytes); err != nil {
+		http.Error(w, "The upload is invalid: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	files := r.MultipartForm.File["file"]
+	if len(files) == 0 {
+		http.Error(w, "at least one file part named \"file\" is required", http.StatusBadRequest)
+		return
+	}
+
+	jobIDs := make([]string, 0, len(files))
+	for _, fh := range files {
+		file, err := fh.Open()
+		if err != nil {
+			http.Error(w, "failed to read uploaded file", http.StatusBadRequest)
+			return
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			http.Error(w, "failed to read uploaded file", http.StatusBadRequest)
+			return
+		}
+
+		mime := fh.Header.Get("Content-Type")
+		if mime == "" {
+			mime = http.DetectContentType(data)
+		}
+
+		jobID, err := s.uploads.Submit(data, mime)
+		if errors.Is(err, uploads.ErrQueueFull) {
+			http.Error(w, "upload queue is full, try again shortly", http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			http.Error(w, "failed to queue upload for processing", http.StatusInternalServerError)
+			return
+		}
+		jobIDs = append(jobIDs, jobID)
+	}
+
+	writeJSONStatus(w, http.StatusAccepted, UploadReceiptResponse{JobIDs: jobIDs})
+}
+
+// UploadJobResponse is the response body for GET /receipts/uploads/{jobId}.
+type UploadJobResponse struct {
+	Status     string  `json:"status"`
+	ReceiptID  string  `json:"receiptId,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// GetUploadJob handles GET /receipts/uploads/{jobId} - Reports the current
+// status of a previously submitted upload.
+func (s *server) GetUploadJob(w http.ResponseWriter, r *http.Request) {
+	jobID := strings.TrimPrefix(r.URL.Path, "/receipts/uploads/")
+	if jobID == "" || jobID == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+
+	job, ok := s.uploads.GetJob(jobID)
+	if !ok {
+		http.Error(w, "No upload job found for that ID.", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(UploadJobResponse{
+		Status:     job.Status,
+		ReceiptID:  job.ReceiptID,
+		Confidence: job.Confidence,
+		Error:      job.Err,
+	})
+}
+
 // RootHandler provides instructions on how to use the API.
 func RootHandler(w http.ResponseWriter, r *http.Request) {
-	fmt.Fprintln(w, "Receipt Processor API is running!\n")
+	fmt.Fprintln(w, "Receipt Processor API is running!")
+	fmt.Fprintln(w)
 
-	fmt.Fprintln(w, "Usage Instructions:\n")
+	fmt.Fprintln(w, "Usage Instructions:")
+	fmt.Fprintln(w)
 
-	fmt.Fprintln(w, "1. Submit a receipt:\n")
+	fmt.Fprintln(w, "1. Submit a receipt:")
+	fmt.Fprintln(w)
 	fmt.Fprintln(w, `   curl -X POST http://localhost:8080/receipts/process -H "Content-Type: application/json" -d '{
        "retailer": "Target",
        "purchaseDate": "2022-01-01",
@@ -167,20 +591,107 @@ func RootHandler(w http.ResponseWriter, r *http.Request) {
            {"shortDescription": "Mountain Dew 12PK", "price": "6.49"}
        ]
    }'`)
-	fmt.Fprintln(w, "\n")
-	fmt.Fprintln(w, "\n")
-	fmt.Fprintln(w, "2. Retrieve receipt points:\n")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "2. Retrieve receipt points:")
+	fmt.Fprintln(w)
 	fmt.Fprintln(w, "   curl -X GET http://localhost:8080/receipts/{id}/points")
-	fmt.Fprintln(w, "   (Replace {id} with the actual receipt ID from the previous response)\n")
+	fmt.Fprintln(w, "   (Replace {id} with the actual receipt ID from the previous response)")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "3. List receipts (paged, filterable):")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "   curl -X GET 'http://localhost:8080/receipts?page=1&pageSize=20&orderBy=submittedAt&sortDirection=desc'")
+}
+
+// receiptsRouter dispatches /receipts and /receipts/ requests by method and
+// path shape, since the list and single-receipt endpoints share a prefix.
+func (s *server) receiptsRouter(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/receipts" && r.Method == http.MethodGet:
+		s.ListReceipts(w, r)
+	case strings.HasSuffix(r.URL.Path, "/points") && r.Method == http.MethodGet:
+		s.GetPoints(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// routes builds the server's handler, wiring every endpoint to s.
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", RootHandler)
+	mux.HandleFunc("/receipts/process", s.ProcessReceipt)
+	mux.HandleFunc("/receipts/bulk-status", s.BulkUpdateStatus)
+	mux.HandleFunc("/receipts/upload", s.UploadReceipt)
+	mux.HandleFunc("/receipts/uploads/", s.GetUploadJob)
+	mux.HandleFunc("/receipts", s.receiptsRouter)
+	mux.HandleFunc("/receipts/", s.receiptsRouter)
+	return mux
 }
 
 func main() {
-	// Register API routes
-	http.HandleFunc("/", RootHandler)
-	http.HandleFunc("/receipts/process", ProcessReceipt)
-	http.HandleFunc("/receipts/", GetPoints)
-
-	// Start server
-	fmt.Println("Server running on port 8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	engine, err := loadScoringEngine()
+	if err != nil {
+		log.Fatalf("failed to load scoring rules: %v", err)
+	}
+
+	storeCfg := loadStoreConfig()
+	if err := storeCfg.Validate(); err != nil {
+		log.Fatalf("invalid store config: %v", err)
+	}
+	receiptStore, err := store.New(storeCfg)
+	if err != nil {
+		log.Fatalf("failed to initialize store: %v", err)
+	}
+
+	uploadCfg := loadUploadConfig()
+	if err := uploadCfg.Validate(); err != nil {
+		log.Fatalf("invalid upload config: %v", err)
+	}
+	uploadMgr, err := uploads.NewManager(uploadCfg, loadOCRBackend(), receiptStore, engine)
+	if err != nil {
+		log.Fatalf("failed to initialize upload pipeline: %v", err)
+	}
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	uploadMgr.Start(workerCtx, uploadCfg.WorkerCount)
+
+	s := &server{store: receiptStore, engine: engine, uploads: uploadMgr}
+
+	httpServer := &http.Server{
+		Addr:         ":8080",
+		Handler:      s.routes(),
+		ReadTimeout:  storeCfg.ReadTimeout,
+		WriteTimeout: storeCfg.WriteTimeout,
+	}
+
+	serverErrs := make(chan error, 1)
+	go func() {
+		fmt.Println("Server running on port 8080")
+		serverErrs <- httpServer.ListenAndServe()
+	}()
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-serverErrs:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("server error: %v", err)
+		}
+	case <-shutdown:
+		fmt.Println("Shutting down gracefully...")
+		ctx, cancel := context.WithTimeout(context.Background(), storeCfg.WriteTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(ctx); err != nil {
+			log.Printf("graceful shutdown failed: %v", err)
+		}
+		// Stop the upload workers before closing the store: they hold no
+		// reference to httpServer and would otherwise keep calling
+		// receiptStore.Save after it's closed.
+		stopWorkers()
+		if err := receiptStore.Close(); err != nil {
+			log.Printf("failed to close store: %v", err)
+		}
+	}
 }
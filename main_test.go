@@ -0,0 +1,610 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"encoding/json"
+
+	"github.com/nwc6624/receipt-processor-challenge/ocr"
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+	"github.com/nwc6624/receipt-processor-challenge/store"
+	"github.com/nwc6624/receipt-processor-challenge/uploads"
+)
+
+const sampleReceiptJSON = `{
+	"retailer": "Target",
+	"purchaseDate": "2022-01-01",
+	"purchaseTime": "13:01",
+	"total": "35.35",
+	"items": [
+		{"shortDescription": "Mountain Dew 12PK", "price": "6.49"}
+	]
+}`
+
+// newTestServer builds a server backed by a fresh in-memory store, loading
+// the repo's default rules config the same way main() does.
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+	cfg, err := rules.LoadConfig("rules.json")
+	if err != nil {
+		t.Fatalf("rules.LoadConfig: %v", err)
+	}
+	engine, err := cfg.BuildEngine()
+	if err != nil {
+		t.Fatalf("BuildEngine: %v", err)
+	}
+	return &server{store: store.NewMemoryStore(), engine: engine}
+}
+
+func postReceipt(t *testing.T, s *server, idempotencyKey string) string {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/receipts/process", bytes.NewBufferString(sampleReceiptJSON))
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	rec := httptest.NewRecorder()
+	s.ProcessReceipt(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d, body: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp["id"]
+}
+
+// postReceiptWithRetailerAndTotal submits a distinct receipt (varying the
+// retailer and total so it doesn't collide with content-hash dedup) and
+// returns its ID.
+func postReceiptWithRetailerAndTotal(t *testing.T, s *server, retailer, total string) string {
+	t.Helper()
+	body := fmt.Sprintf(`{
+		"retailer": %q,
+		"purchaseDate": "2022-01-01",
+		"purchaseTime": "13:01",
+		"total": %q,
+		"items": [
+			{"shortDescription": "Mountain Dew 12PK", "price": "6.49"}
+		]
+	}`, retailer, total)
+	req := httptest.NewRequest(http.MethodPost, "/receipts/process", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	s.ProcessReceipt(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d, body: %s", rec.Code, rec.Body.String())
+	}
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp["id"]
+}
+
+// listReceipts issues a GET /receipts?<query> and decodes the response.
+func listReceipts(t *testing.T, s *server, query string) (int, ListReceiptsResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/receipts?"+query, nil)
+	rec := httptest.NewRecorder()
+	s.ListReceipts(rec, req)
+	if rec.Code != http.StatusOK {
+		return rec.Code, ListReceiptsResponse{}
+	}
+	var resp ListReceiptsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return rec.Code, resp
+}
+
+// TestListReceipts_FiltersByRetailer verifies GET /receipts?retailer=...
+// returns only receipts from that retailer.
+func TestListReceipts_FiltersByRetailer(t *testing.T) {
+	s := newTestServer(t)
+	postReceiptWithRetailerAndTotal(t, s, "Target", "10.00")
+	postReceiptWithRetailerAndTotal(t, s, "Walmart", "20.00")
+	postReceiptWithRetailerAndTotal(t, s, "Target", "30.00")
+
+	code, resp := listReceipts(t, s, "retailer=Target")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	if resp.TotalCount != 2 {
+		t.Fatalf("expected 2 matching receipts, got %d", resp.TotalCount)
+	}
+	for _, r := range resp.Receipts {
+		if r.Receipt.Retailer != "Target" {
+			t.Fatalf("expected only Target receipts, got %+v", r)
+		}
+	}
+}
+
+// TestListReceipts_SortsAndPaginates verifies GET /receipts honors orderBy,
+// sortDirection, page and pageSize.
+func TestListReceipts_SortsAndPaginates(t *testing.T) {
+	s := newTestServer(t)
+	postReceiptWithRetailerAndTotal(t, s, "Retailer A", "10.00")
+	postReceiptWithRetailerAndTotal(t, s, "Retailer B", "20.00")
+	postReceiptWithRetailerAndTotal(t, s, "Retailer C", "30.00")
+
+	code, page1 := listReceipts(t, s, "page=1&pageSize=2&orderBy=total&sortDirection=asc")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	if page1.TotalCount != 3 || len(page1.Receipts) != 2 {
+		t.Fatalf("expected 3 total and 2 on the first page, got total=%d len=%d", page1.TotalCount, len(page1.Receipts))
+	}
+	if page1.Receipts[0].Receipt.Total != "10.00" || page1.Receipts[1].Receipt.Total != "20.00" {
+		t.Fatalf("expected ascending order by total on page 1, got %+v", page1.Receipts)
+	}
+
+	code, page2 := listReceipts(t, s, "page=2&pageSize=2&orderBy=total&sortDirection=asc")
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	if len(page2.Receipts) != 1 || page2.Receipts[0].Receipt.Total != "30.00" {
+		t.Fatalf("expected the remaining receipt on page 2, got %+v", page2.Receipts)
+	}
+}
+
+// TestListReceipts_RejectsInvalidQueryParams verifies malformed pagination
+// and sort parameters are rejected with 400, not silently clamped.
+func TestListReceipts_RejectsInvalidQueryParams(t *testing.T) {
+	s := newTestServer(t)
+
+	cases := []string{
+		"page=0",
+		"page=9223372036854775807",
+		"pageSize=0",
+		"pageSize=101",
+		"sortDirection=sideways",
+		"orderBy=notAField",
+	}
+	for _, query := range cases {
+		code, _ := listReceipts(t, s, query)
+		if code != http.StatusBadRequest {
+			t.Errorf("query %q: expected 400, got %d", query, code)
+		}
+	}
+}
+
+// bulkUpdateStatus issues a POST /receipts/bulk-status and decodes the
+// response.
+func bulkUpdateStatus(t *testing.T, s *server, body string) (int, BulkStatusResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/receipts/bulk-status", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	s.BulkUpdateStatus(rec, req)
+	if rec.Code != http.StatusOK {
+		return rec.Code, BulkStatusResponse{}
+	}
+	var resp BulkStatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return rec.Code, resp
+}
+
+// TestBulkUpdateStatus_UpdatesAndReportsNotFound verifies a mixed batch of
+// known and unknown IDs is partitioned correctly.
+func TestBulkUpdateStatus_UpdatesAndReportsNotFound(t *testing.T) {
+	s := newTestServer(t)
+	id := postReceiptWithRetailerAndTotal(t, s, "Target", "10.00")
+
+	code, resp := bulkUpdateStatus(t, s, fmt.Sprintf(
+		`{"receiptIds": [%q, "does-not-exist"], "status": %q, "comment": "looks off"}`,
+		id, store.StatusNeedsAttention))
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", code)
+	}
+	if len(resp.Updated) != 1 || resp.Updated[0] != id {
+		t.Fatalf("expected %q to be updated, got %+v", id, resp.Updated)
+	}
+	if len(resp.NotFound) != 1 || resp.NotFound[0] != "does-not-exist" {
+		t.Fatalf("expected does-not-exist to be reported not found, got %+v", resp.NotFound)
+	}
+
+	sr, ok, err := s.store.Get(id)
+	if err != nil || !ok {
+		t.Fatalf("Get: ok=%v, err=%v", ok, err)
+	}
+	if sr.Status != store.StatusNeedsAttention || sr.Comment != "looks off" {
+		t.Fatalf("expected status/comment to be persisted, got %+v", sr)
+	}
+}
+
+// TestBulkUpdateStatus_RejectsInvalidStatus verifies an unrecognized status
+// value is rejected with 400 rather than silently stored.
+func TestBulkUpdateStatus_RejectsInvalidStatus(t *testing.T) {
+	s := newTestServer(t)
+	id := postReceiptWithRetailerAndTotal(t, s, "Target", "10.00")
+
+	code, _ := bulkUpdateStatus(t, s, fmt.Sprintf(`{"receiptIds": [%q], "status": "NotARealStatus"}`, id))
+	if code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid status, got %d", code)
+	}
+}
+
+// TestBulkUpdateStatus_RejectsEmptyReceiptIDs verifies an empty receiptIds
+// list is rejected with 400.
+func TestBulkUpdateStatus_RejectsEmptyReceiptIDs(t *testing.T) {
+	s := newTestServer(t)
+
+	code, _ := bulkUpdateStatus(t, s, fmt.Sprintf(`{"receiptIds": [], "status": %q}`, store.StatusOpen))
+	if code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an empty receiptIds list, got %d", code)
+	}
+}
+
+// TestProcessReceipt_DuplicateContentCollapses verifies that submitting the
+// same receipt content twice returns the original ID instead of minting a
+// new one.
+func TestProcessReceipt_DuplicateContentCollapses(t *testing.T) {
+	s := newTestServer(t)
+
+	firstID := postReceipt(t, s, "")
+	secondID := postReceipt(t, s, "")
+
+	if firstID != secondID {
+		t.Fatalf("expected duplicate submission to return the same ID, got %q and %q", firstID, secondID)
+	}
+
+	_, total, err := s.store.List(store.Filter{}, store.Page{Number: 1, Size: 10, OrderBy: "submittedAt"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected exactly 1 stored receipt, got %d", total)
+	}
+}
+
+// TestProcessReceipt_IdempotencyKeyReplay verifies that replaying a POST with
+// the same Idempotency-Key returns the original ID.
+func TestProcessReceipt_IdempotencyKeyReplay(t *testing.T) {
+	s := newTestServer(t)
+
+	firstID := postReceipt(t, s, "key-123")
+	secondID := postReceipt(t, s, "key-123")
+
+	if firstID != secondID {
+		t.Fatalf("expected idempotency key replay to return the same ID, got %q and %q", firstID, secondID)
+	}
+}
+
+// TestProcessReceipt_ConcurrentDuplicatesCollapseToOne submits the same
+// receipt concurrently from many goroutines and asserts they all collapse to
+// a single stored receipt, proving the check-then-insert path is
+// goroutine-safe.
+func TestProcessReceipt_ConcurrentDuplicatesCollapseToOne(t *testing.T) {
+	s := newTestServer(t)
+
+	const concurrency = 50
+	ids := make([]string, concurrency)
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func(i int) {
+			defer wg.Done()
+			ids[i] = postReceipt(t, s, "")
+		}(i)
+	}
+	wg.Wait()
+
+	first := ids[0]
+	for _, id := range ids {
+		if id != first {
+			t.Fatalf("expected all concurrent duplicate submits to collapse to one ID, got %q and %q", first, id)
+		}
+	}
+
+	_, total, err := s.store.List(store.Filter{}, store.Page{Number: 1, Size: 10, OrderBy: "submittedAt"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected exactly 1 stored receipt after concurrent duplicate submits, got %d", total)
+	}
+}
+
+// getPoints issues GET /receipts/{id}/points, optionally with ?explain=true,
+// and decodes the response.
+func getPoints(t *testing.T, s *server, id string, explain bool) (int, PointsExplainResponse) {
+	t.Helper()
+	path := "/receipts/" + id + "/points"
+	if explain {
+		path += "?explain=true"
+	}
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	s.GetPoints(rec, req)
+	if rec.Code != http.StatusOK {
+		return rec.Code, PointsExplainResponse{}
+	}
+	if explain {
+		var resp PointsExplainResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return rec.Code, resp
+	}
+	var resp map[string]int
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return rec.Code, PointsExplainResponse{Points: resp["points"]}
+}
+
+// TestGetPoints_PlainAndExplainAgree verifies the explain breakdown sums to
+// the same total the plain (non-explain) response reports.
+func TestGetPoints_PlainAndExplainAgree(t *testing.T) {
+	s := newTestServer(t)
+	id := postReceiptWithRetailerAndTotal(t, s, "Target", "10.00")
+
+	plainCode, plain := getPoints(t, s, id, false)
+	if plainCode != http.StatusOK {
+		t.Fatalf("plain GetPoints: expected 200, got %d", plainCode)
+	}
+	explainCode, explain := getPoints(t, s, id, true)
+	if explainCode != http.StatusOK {
+		t.Fatalf("explain GetPoints: expected 200, got %d", explainCode)
+	}
+
+	if explain.Points != plain.Points {
+		t.Fatalf("explain reported %d points but plain reported %d", explain.Points, plain.Points)
+	}
+	var sum int
+	for _, e := range explain.Explanation {
+		sum += e.Points
+	}
+	if sum != explain.Points {
+		t.Fatalf("explanation breakdown sums to %d, but reported total is %d", sum, explain.Points)
+	}
+}
+
+// TestGetPoints_ExplainSurvivesEngineChange verifies that changing s.engine
+// after a receipt is submitted (e.g. promoting a new rules.json and
+// restarting) doesn't change what GetPoints reports for that receipt: both
+// the plain and explain responses must keep replaying the scoring that
+// happened at submission time, not recompute against the engine loaded now.
+func TestGetPoints_ExplainSurvivesEngineChange(t *testing.T) {
+	s := newTestServer(t)
+	id := postReceiptWithRetailerAndTotal(t, s, "Target", "10.00")
+
+	_, before := getPoints(t, s, id, true)
+
+	// Swap in an engine with no rules at all - if explain recomputed against
+	// the current engine, this would make it report 0 points.
+	s.engine = rules.NewEngine(nil)
+
+	plainCode, plain := getPoints(t, s, id, false)
+	if plainCode != http.StatusOK {
+		t.Fatalf("plain GetPoints: expected 200, got %d", plainCode)
+	}
+	explainCode, explain := getPoints(t, s, id, true)
+	if explainCode != http.StatusOK {
+		t.Fatalf("explain GetPoints: expected 200, got %d", explainCode)
+	}
+
+	if plain.Points != before.Points {
+		t.Fatalf("plain points changed after engine swap: got %d, want %d", plain.Points, before.Points)
+	}
+	if explain.Points != before.Points {
+		t.Fatalf("explain points changed after engine swap: got %d, want %d", explain.Points, before.Points)
+	}
+}
+
+// TestGetPoints_NotFound verifies an unknown receipt ID yields 404.
+func TestGetPoints_NotFound(t *testing.T) {
+	s := newTestServer(t)
+	code, _ := getPoints(t, s, "does-not-exist", false)
+	if code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", code)
+	}
+}
+
+// fakeOCR returns a fixed receipt and confidence, or an error, without
+// touching any external binary or service.
+type fakeOCR struct {
+	receipt    rules.Receipt
+	confidence float64
+	err        error
+}
+
+func (f fakeOCR) Extract(ctx context.Context, data []byte, mime string) (rules.Receipt, float64, error) {
+	return f.receipt, f.confidence, f.err
+}
+
+// sampleReceipt returns a Receipt suitable for a fake OCR backend to return.
+func sampleReceipt() Receipt {
+	return Receipt{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        "35.35",
+		Items: []Item{
+			{ShortDescription: "Mountain Dew 12PK", Price: "6.49"},
+		},
+	}
+}
+
+// newTestServerWithUploads builds a server like newTestServer, but also wires
+// up an uploads.Manager backed by ocrBackend so UploadReceipt and
+// GetUploadJob can be exercised. Workers are started unless startWorkers is
+// false, which leaves submitted jobs sitting in the queue - useful for
+// deterministically testing a full queue without racing a worker that might
+// drain it first.
+func newTestServerWithUploads(t *testing.T, ocrBackend ocr.OCR, queueDepth int, startWorkers bool) *server {
+	t.Helper()
+	s := newTestServer(t)
+
+	mgr, err := uploads.NewManager(uploads.Config{
+		BlobDir:     filepath.Join(t.TempDir(), "blobs"),
+		WorkerCount: 1,
+		QueueDepth:  queueDepth,
+		OCRTimeout:  time.Second,
+	}, ocrBackend, s.store, s.engine)
+	if err != nil {
+		t.Fatalf("uploads.NewManager: %v", err)
+	}
+	if startWorkers {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		mgr.Start(ctx, 1)
+	}
+
+	s.uploads = mgr
+	return s
+}
+
+// multipartUploadRequest builds a POST /receipts/upload request carrying one
+// "file" part named filename with the given content.
+func multipartUploadRequest(t *testing.T, filename string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("CreateFormFile: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("writing form file: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing multipart writer: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/receipts/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return req
+}
+
+// getUploadJob issues GET /receipts/uploads/{jobId} and decodes the response.
+func getUploadJob(t *testing.T, s *server, jobID string) (int, UploadJobResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/receipts/uploads/"+jobID, nil)
+	rec := httptest.NewRecorder()
+	s.GetUploadJob(rec, req)
+	if rec.Code != http.StatusOK {
+		return rec.Code, UploadJobResponse{}
+	}
+	var resp UploadJobResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return rec.Code, resp
+}
+
+// waitForUploadStatus polls GetUploadJob until it reports a status other than
+// Pending, or fails the test after a timeout.
+func waitForUploadStatus(t *testing.T, s *server, jobID string) UploadJobResponse {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		code, job := getUploadJob(t, s, jobID)
+		if code != http.StatusOK {
+			t.Fatalf("GetUploadJob: expected 200, got %d", code)
+		}
+		if job.Status != uploads.StatusPending {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for job %s to leave pending", jobID)
+	return UploadJobResponse{}
+}
+
+// TestUploadReceipt_AcceptsAndProcessesFile verifies a multipart upload is
+// accepted with 202 and job IDs, and that the job eventually reports Done
+// with the receipt ID and confidence from OCR extraction.
+func TestUploadReceipt_AcceptsAndProcessesFile(t *testing.T) {
+	s := newTestServerWithUploads(t, fakeOCR{receipt: sampleReceipt(), confidence: 0.85}, 10, true)
+
+	req := multipartUploadRequest(t, "receipt.jpg", []byte("fake image bytes"))
+	rec := httptest.NewRecorder()
+	s.UploadReceipt(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d, body: %s", rec.Code, rec.Body.String())
+	}
+	var resp UploadReceiptResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.JobIDs) != 1 {
+		t.Fatalf("expected 1 job ID, got %d", len(resp.JobIDs))
+	}
+
+	job := waitForUploadStatus(t, s, resp.JobIDs[0])
+	if job.Status != uploads.StatusDone {
+		t.Fatalf("expected job to finish done, got %+v", job)
+	}
+	if job.ReceiptID == "" {
+		t.Fatal("expected a receiptId on a done job")
+	}
+	if job.Confidence != 0.85 {
+		t.Fatalf("expected confidence 0.85, got %v", job.Confidence)
+	}
+}
+
+// TestUploadReceipt_RejectsMissingFilePart verifies a multipart body with no
+// "file" part is rejected with 400.
+func TestUploadReceipt_RejectsMissingFilePart(t *testing.T) {
+	s := newTestServerWithUploads(t, fakeOCR{receipt: sampleReceipt(), confidence: 0.85}, 10, true)
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.WriteField("notFile", "irrelevant"); err != nil {
+		t.Fatalf("WriteField: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing multipart writer: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/receipts/upload", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	rec := httptest.NewRecorder()
+	s.UploadReceipt(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d, body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// TestUploadReceipt_RejectsFullQueue verifies a full upload queue yields 503
+// instead of blocking or panicking. Workers are left unstarted so the queue
+// fills deterministically instead of racing a worker that might drain it.
+func TestUploadReceipt_RejectsFullQueue(t *testing.T) {
+	s := newTestServerWithUploads(t, fakeOCR{receipt: sampleReceipt(), confidence: 0.5}, 1, false)
+
+	req1 := multipartUploadRequest(t, "receipt1.jpg", []byte("a"))
+	rec1 := httptest.NewRecorder()
+	s.UploadReceipt(rec1, req1)
+	if rec1.Code != http.StatusAccepted {
+		t.Fatalf("first upload: expected 202, got %d, body: %s", rec1.Code, rec1.Body.String())
+	}
+
+	req2 := multipartUploadRequest(t, "receipt2.jpg", []byte("b"))
+	rec2 := httptest.NewRecorder()
+	s.UploadReceipt(rec2, req2)
+	if rec2.Code != http.StatusServiceUnavailable {
+		t.Fatalf("second upload: expected 503, got %d, body: %s", rec2.Code, rec2.Body.String())
+	}
+}
+
+// TestGetUploadJob_NotFound verifies an unknown job ID yields 404.
+func TestGetUploadJob_NotFound(t *testing.T) {
+	s := newTestServerWithUploads(t, fakeOCR{receipt: sampleReceipt(), confidence: 0.85}, 10, true)
+	code, _ := getUploadJob(t, s, "does-not-exist")
+	if code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", code)
+	}
+}
@@ -0,0 +1,17 @@
+// Package ocr extracts structured receipt data from scanned images and PDFs,
+// so the API can accept raw photos of physical receipts in addition to
+// pre-parsed JSON.
+package ocr
+
+import (
+	"context"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// OCR turns the raw bytes of a scanned receipt into a rules.Receipt, along
+// with a confidence score in [0, 1] for how much the caller should trust the
+// extraction.
+type OCR interface {
+	Extract(ctx context.Context, data []byte, mime string) (rules.Receipt, float64, error)
+}
@@ -0,0 +1,80 @@
+package ocr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+var (
+	isoDateRegex   = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`)
+	slashDateRegex = regexp.MustCompile(`\b(\d{2})/(\d{2})/(\d{4})\b`)
+	timeRegex      = regexp.MustCompile(`\b([01]?\d|2[0-3]):([0-5]\d)\b`)
+	totalRegex     = regexp.MustCompile(`(?i)total\D{0,40}(\d+\.\d{2})`)
+	priceRegex     = regexp.MustCompile(`(\d+\.\d{2})\s*$`)
+)
+
+// parseReceiptText heuristically maps raw OCR text into a rules.Receipt,
+// returning a confidence score in [0, 1] based on how many fields it managed
+// to extract. It is intentionally forgiving: physical receipts OCR
+// imperfectly, and a partial extraction is still useful to a human reviewer.
+func parseReceiptText(text string) (rules.Receipt, float64) {
+	var receipt rules.Receipt
+	found := 0
+	const wantedFields = 4
+
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && receipt.Retailer == "" {
+			receipt.Retailer = trimmed
+			found++
+			break
+		}
+	}
+
+	if m := isoDateRegex.FindStringSubmatch(text); m != nil {
+		receipt.PurchaseDate = m[1]
+		found++
+	} else if m := slashDateRegex.FindStringSubmatch(text); m != nil {
+		receipt.PurchaseDate = m[3] + "-" + m[1] + "-" + m[2]
+		found++
+	}
+
+	if m := timeRegex.FindStringSubmatch(text); m != nil {
+		receipt.PurchaseTime = m[1] + ":" + m[2]
+		found++
+	}
+
+	if m := totalRegex.FindStringSubmatch(text); m != nil {
+		receipt.Total = m[1]
+		found++
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		m := priceRegex.FindStringSubmatch(trimmed)
+		if m == nil {
+			continue
+		}
+		if _, err := strconv.ParseFloat(m[1], 64); err != nil {
+			continue
+		}
+		desc := strings.TrimSpace(strings.TrimSuffix(trimmed, m[1]))
+		if desc == "" || strings.EqualFold(desc, "total") {
+			continue
+		}
+		receipt.Items = append(receipt.Items, rules.Item{ShortDescription: desc, Price: m[1]})
+	}
+
+	confidence := float64(found) / float64(wantedFields)
+	if len(receipt.Items) > 0 {
+		confidence = (confidence*wantedFields + 1) / (wantedFields + 1)
+	}
+	return receipt, confidence
+}
@@ -0,0 +1,37 @@
+package ocr
+
+import "testing"
+
+func TestParseReceiptText(t *testing.T) {
+	text := "Target\n2022-01-01 13:01\nMountain Dew 12PK          6.49\nTOTAL                     35.35\n"
+
+	receipt, confidence := parseReceiptText(text)
+
+	if receipt.Retailer != "Target" {
+		t.Errorf("expected retailer Target, got %q", receipt.Retailer)
+	}
+	if receipt.PurchaseDate != "2022-01-01" {
+		t.Errorf("expected purchaseDate 2022-01-01, got %q", receipt.PurchaseDate)
+	}
+	if receipt.PurchaseTime != "13:01" {
+		t.Errorf("expected purchaseTime 13:01, got %q", receipt.PurchaseTime)
+	}
+	if receipt.Total != "35.35" {
+		t.Errorf("expected total 35.35, got %q", receipt.Total)
+	}
+	if len(receipt.Items) != 1 || receipt.Items[0].Price != "6.49" {
+		t.Errorf("expected one item priced 6.49, got %+v", receipt.Items)
+	}
+	if confidence <= 0 {
+		t.Errorf("expected positive confidence, got %f", confidence)
+	}
+}
+
+func TestParseReceiptText_PartialInputLowersConfidence(t *testing.T) {
+	_, fullConfidence := parseReceiptText("Target\n2022-01-01 13:01\nTOTAL 35.35\n")
+	_, partialConfidence := parseReceiptText("some garbled text with no fields")
+
+	if partialConfidence >= fullConfidence {
+		t.Errorf("expected partial extraction (%f) to score lower than full extraction (%f)", partialConfidence, fullConfidence)
+	}
+}
@@ -0,0 +1,53 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// RemoteOCR delegates extraction to an HTTP OCR service, POSTing the raw
+// bytes and expecting a JSON response of {"receipt": {...}, "confidence": 0.9}.
+type RemoteOCR struct {
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+type remoteOCRResponse struct {
+	Receipt    rules.Receipt `json:"receipt"`
+	Confidence float64       `json:"confidence"`
+}
+
+// Extract POSTs data to r.Endpoint and decodes the resulting Receipt.
+func (r RemoteOCR) Extract(ctx context.Context, data []byte, mime string) (rules.Receipt, float64, error) {
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return rules.Receipt{}, 0, fmt.Errorf("remote ocr: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", mime)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return rules.Receipt{}, 0, fmt.Errorf("remote ocr: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return rules.Receipt{}, 0, fmt.Errorf("remote ocr: unexpected status %d", resp.StatusCode)
+	}
+
+	var decoded remoteOCRResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return rules.Receipt{}, 0, fmt.Errorf("remote ocr: decoding response: %w", err)
+	}
+	return decoded.Receipt, decoded.Confidence, nil
+}
@@ -0,0 +1,74 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// TesseractOCR shells out to the tesseract CLI to extract text from an
+// image, then heuristically maps that text into a Receipt.
+type TesseractOCR struct {
+	// BinaryPath is the tesseract executable to run. Defaults to "tesseract"
+	// on PATH if empty.
+	BinaryPath string
+}
+
+// Extract writes data to a temp file, runs tesseract against it, and parses
+// the resulting text into a Receipt.
+func (t TesseractOCR) Extract(ctx context.Context, data []byte, mime string) (rules.Receipt, float64, error) {
+	binary := t.BinaryPath
+	if binary == "" {
+		binary = "tesseract"
+	}
+
+	tmpFile, err := os.CreateTemp("", "receipt-ocr-*"+ExtensionForMIME(mime))
+	if err != nil {
+		return rules.Receipt{}, 0, fmt.Errorf("tesseract: creating temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return rules.Receipt{}, 0, fmt.Errorf("tesseract: writing temp file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return rules.Receipt{}, 0, fmt.Errorf("tesseract: closing temp file: %w", err)
+	}
+
+	// "stdout" tells tesseract to write recognized text to stdout instead of
+	// a file.
+	cmd := exec.CommandContext(ctx, binary, tmpFile.Name(), "stdout")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return rules.Receipt{}, 0, fmt.Errorf("tesseract: %w: %s", err, stderr.String())
+	}
+
+	receipt, confidence := parseReceiptText(stdout.String())
+	return receipt, confidence, nil
+}
+
+// ExtensionForMIME returns a reasonable file extension for a MIME type. It's
+// exported so callers that persist uploads to disk (the uploads package) and
+// TesseractOCR's temp-file hint agree on naming.
+func ExtensionForMIME(mime string) string {
+	switch mime {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/heic":
+		return ".heic"
+	case "application/pdf":
+		return ".pdf"
+	default:
+		return ".bin"
+	}
+}
@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var alphanumericRegex = regexp.MustCompile("[a-zA-Z0-9]")
+
+// AlphanumericRetailer awards PointsPerChar for every alphanumeric character
+// in the retailer name.
+type AlphanumericRetailer struct {
+	PointsPerChar int
+}
+
+func (r AlphanumericRetailer) Name() string { return "alphanumeric_retailer" }
+
+func (r AlphanumericRetailer) Apply(receipt Receipt) int {
+	return len(alphanumericRegex.FindAllString(receipt.Retailer, -1)) * r.PointsPerChar
+}
+
+// Validate reports whether r's params are usable.
+func (r AlphanumericRetailer) Validate() error {
+	if r.PointsPerChar < 0 {
+		return fmt.Errorf("PointsPerChar must not be negative")
+	}
+	return nil
+}
+
+// RoundDollarBonus awards Points if the total is a round dollar amount with
+// no cents.
+type RoundDollarBonus struct {
+	Points int
+}
+
+func (r RoundDollarBonus) Name() string { return "round_dollar_bonus" }
+
+func (r RoundDollarBonus) Apply(receipt Receipt) int {
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+	if math.Mod(total, 1) == 0 {
+		return r.Points
+	}
+	return 0
+}
+
+// Validate reports whether r's params are usable.
+func (r RoundDollarBonus) Validate() error {
+	if r.Points < 0 {
+		return fmt.Errorf("Points must not be negative")
+	}
+	return nil
+}
+
+// QuarterMultiple awards Points if the total is a multiple of 0.25.
+type QuarterMultiple struct {
+	Points int
+}
+
+func (r QuarterMultiple) Name() string { return "quarter_multiple" }
+
+func (r QuarterMultiple) Apply(receipt Receipt) int {
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+	if math.Mod(total, 0.25) == 0 {
+		return r.Points
+	}
+	return 0
+}
+
+// Validate reports whether r's params are usable.
+func (r QuarterMultiple) Validate() error {
+	if r.Points < 0 {
+		return fmt.Errorf("Points must not be negative")
+	}
+	return nil
+}
+
+// ItemPairBonus awards PointsPerPair for every two items on the receipt.
+type ItemPairBonus struct {
+	PointsPerPair int
+}
+
+func (r ItemPairBonus) Name() string { return "item_pair_bonus" }
+
+func (r ItemPairBonus) Apply(receipt Receipt) int {
+	return (len(receipt.Items) / 2) * r.PointsPerPair
+}
+
+// Validate reports whether r's params are usable.
+func (r ItemPairBonus) Validate() error {
+	if r.PointsPerPair < 0 {
+		return fmt.Errorf("PointsPerPair must not be negative")
+	}
+	return nil
+}
+
+// ItemDescriptionLengthBonus awards ceil(price*PriceMultiplier) points for
+// every item whose trimmed description length is a multiple of Multiple.
+type ItemDescriptionLengthBonus struct {
+	Multiple        int
+	PriceMultiplier float64
+}
+
+func (r ItemDescriptionLengthBonus) Name() string { return "item_description_length_bonus" }
+
+func (r ItemDescriptionLengthBonus) Apply(receipt Receipt) int {
+	points := 0
+	for _, item := range receipt.Items {
+		desc := strings.TrimSpace(item.ShortDescription)
+		if len(desc)%r.Multiple != 0 {
+			continue
+		}
+		price, _ := strconv.ParseFloat(item.Price, 64)
+		points += int(math.Ceil(price*r.PriceMultiplier + 0.0001)) // Fix rounding issue
+	}
+	return points
+}
+
+// Validate reports whether r's params are usable. In particular, Multiple
+// must not be zero: Apply uses it as a modulus divisor, and a zero Multiple
+// would panic with "integer divide by zero" on the first receipt scored.
+func (r ItemDescriptionLengthBonus) Validate() error {
+	if r.Multiple == 0 {
+		return fmt.Errorf("Multiple must not be zero")
+	}
+	if r.PriceMultiplier < 0 {
+		return fmt.Errorf("PriceMultiplier must not be negative")
+	}
+	return nil
+}
+
+// OddDayBonus awards Points if the purchase day of month is odd.
+type OddDayBonus struct {
+	Points int
+}
+
+func (r OddDayBonus) Name() string { return "odd_day_bonus" }
+
+func (r OddDayBonus) Apply(receipt Receipt) int {
+	dateParts := strings.Split(receipt.PurchaseDate, "-")
+	if len(dateParts) != 3 {
+		return 0
+	}
+	day, _ := strconv.Atoi(dateParts[2])
+	if day%2 == 1 {
+		return r.Points
+	}
+	return 0
+}
+
+// Validate reports whether r's params are usable.
+func (r OddDayBonus) Validate() error {
+	if r.Points < 0 {
+		return fmt.Errorf("Points must not be negative")
+	}
+	return nil
+}
+
+// AfternoonWindow awards Points if the purchase time falls within
+// [Start, End), both formatted as "HH:MM".
+type AfternoonWindow struct {
+	Start  string
+	End    string
+	Points int
+}
+
+func (r AfternoonWindow) Name() string { return "afternoon_window" }
+
+func (r AfternoonWindow) Apply(receipt Receipt) int {
+	t, err := time.Parse("15:04", receipt.PurchaseTime)
+	if err != nil {
+		return 0
+	}
+	start, err := time.Parse("15:04", r.Start)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse("15:04", r.End)
+	if err != nil {
+		return 0
+	}
+	if (t.Equal(start) || t.After(start)) && t.Before(end) {
+		return r.Points
+	}
+	return 0
+}
+
+// Validate reports whether r's params are usable.
+func (r AfternoonWindow) Validate() error {
+	if _, err := time.Parse("15:04", r.Start); err != nil {
+		return fmt.Errorf("Start must be formatted as HH:MM: %w", err)
+	}
+	if _, err := time.Parse("15:04", r.End); err != nil {
+		return fmt.Errorf("End must be formatted as HH:MM: %w", err)
+	}
+	if r.Points < 0 {
+		return fmt.Errorf("Points must not be negative")
+	}
+	return nil
+}
+
+// TotalGreaterThan awards Points if the total is strictly greater than
+// Threshold.
+type TotalGreaterThan struct {
+	Threshold float64
+	Points    int
+}
+
+func (r TotalGreaterThan) Name() string { return "total_greater_than" }
+
+func (r TotalGreaterThan) Apply(receipt Receipt) int {
+	total, _ := strconv.ParseFloat(receipt.Total, 64)
+	if total > r.Threshold {
+		return r.Points
+	}
+	return 0
+}
+
+// Validate reports whether r's params are usable.
+func (r TotalGreaterThan) Validate() error {
+	if r.Threshold < 0 {
+		return fmt.Errorf("Threshold must not be negative")
+	}
+	if r.Points < 0 {
+		return fmt.Errorf("Points must not be negative")
+	}
+	return nil
+}
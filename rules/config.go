@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// RuleConfig is one entry in a Config's rule list: which built-in rule to
+// instantiate, whether it's enabled, and its rule-specific parameters.
+type RuleConfig struct {
+	Type    string          `json:"type"`
+	Enabled bool            `json:"enabled"`
+	Params  json.RawMessage `json:"params"`
+}
+
+// Config is the on-disk configuration for the scoring engine, loaded once at
+// startup.
+type Config struct {
+	Rules []RuleConfig `json:"rules"`
+}
+
+// knownRuleTypes lists every built-in rule Type a Config may reference.
+var knownRuleTypes = map[string]bool{
+	"alphanumeric_retailer":         true,
+	"round_dollar_bonus":            true,
+	"quarter_multiple":              true,
+	"item_pair_bonus":               true,
+	"item_description_length_bonus": true,
+	"odd_day_bonus":                 true,
+	"afternoon_window":              true,
+	"total_greater_than":            true,
+}
+
+// Validate checks that every rule in c references a known Type and carries
+// params.
+func (c *Config) Validate() error {
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("rules config must declare at least one rule")
+	}
+	for i, rc := range c.Rules {
+		if rc.Type == "" {
+			return fmt.Errorf("rules[%d]: type must not be empty", i)
+		}
+		if !knownRuleTypes[rc.Type] {
+			return fmt.Errorf("rules[%d]: unknown rule type %q", i, rc.Type)
+		}
+	}
+	return nil
+}
+
+// LoadConfig reads and validates a rules config file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing rules config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rules config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// BuildEngine instantiates the enabled rules in c, in declared order, and
+// returns an Engine that applies them.
+func (c *Config) BuildEngine() (*Engine, error) {
+	enabled := make([]Rule, 0, len(c.Rules))
+	for i, rc := range c.Rules {
+		if !rc.Enabled {
+			continue
+		}
+		rule, err := buildRule(rc)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d] (%s): %w", i, rc.Type, err)
+		}
+		enabled = append(enabled, rule)
+	}
+	return NewEngine(enabled), nil
+}
+
+// buildRule unmarshals rc.Params into the concrete Rule type for rc.Type and
+// validates it, so a bad config (e.g. a zero divisor) fails at startup
+// instead of panicking on the first receipt scored.
+func buildRule(rc RuleConfig) (Rule, error) {
+	switch rc.Type {
+	case "alphanumeric_retailer":
+		var p AlphanumericRetailer
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "round_dollar_bonus":
+		var p RoundDollarBonus
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "quarter_multiple":
+		var p QuarterMultiple
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "item_pair_bonus":
+		var p ItemPairBonus
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "item_description_length_bonus":
+		var p ItemDescriptionLengthBonus
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "odd_day_bonus":
+		var p OddDayBonus
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "afternoon_window":
+		var p AfternoonWindow
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	case "total_greater_than":
+		var p TotalGreaterThan
+		if err := json.Unmarshal(rc.Params, &p); err != nil {
+			return nil, err
+		}
+		return p, p.Validate()
+	default:
+		return nil, fmt.Errorf("unknown rule type %q", rc.Type)
+	}
+}
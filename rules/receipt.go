@@ -0,0 +1,20 @@
+// Package rules implements the receipt scoring engine: an ordered, config
+// driven set of Rule implementations that each contribute points toward a
+// receipt's total score.
+package rules
+
+// Receipt represents the structure of a receipt submitted by the user. It is
+// the canonical definition shared by the HTTP layer and the scoring rules.
+type Receipt struct {
+	Retailer     string `json:"retailer"`
+	PurchaseDate string `json:"purchaseDate"`
+	PurchaseTime string `json:"purchaseTime"`
+	Total        string `json:"total"`
+	Items        []Item `json:"items"`
+}
+
+// Item represents an individual item on a receipt.
+type Item struct {
+	ShortDescription string `json:"shortDescription"`
+	Price            string `json:"price"`
+}
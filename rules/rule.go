@@ -0,0 +1,48 @@
+package rules
+
+// Rule is a single scoring rule that contributes points for a receipt. Each
+// built-in rule is driven entirely by the parameters it was constructed
+// with, so promotions and A/B tests only require a config change and a
+// restart, not a recompile.
+type Rule interface {
+	// Name identifies the rule, e.g. in an explain breakdown.
+	Name() string
+	// Apply returns the number of points this rule contributes for receipt.
+	Apply(receipt Receipt) int
+}
+
+// Explanation is one rule's contribution to a receipt's score, as returned by
+// Engine.Explain.
+type Explanation struct {
+	Rule   string `json:"rule"`
+	Points int    `json:"points"`
+}
+
+// Engine applies an ordered list of enabled rules to a receipt.
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine builds an Engine that applies rules in the given order.
+func NewEngine(rules []Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Score returns the total points a receipt earns across every rule in e.
+func (e *Engine) Score(receipt Receipt) int {
+	total, _ := e.Explain(receipt)
+	return total
+}
+
+// Explain returns the total points a receipt earns along with a breakdown of
+// each rule's individual contribution, in rule order.
+func (e *Engine) Explain(receipt Receipt) (int, []Explanation) {
+	total := 0
+	explanations := make([]Explanation, 0, len(e.rules))
+	for _, rule := range e.rules {
+		points := rule.Apply(receipt)
+		total += points
+		explanations = append(explanations, Explanation{Rule: rule.Name(), Points: points})
+	}
+	return total, explanations
+}
@@ -0,0 +1,77 @@
+package rules
+
+import "testing"
+
+func sampleReceipt() Receipt {
+	return Receipt{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        "35.35",
+		Items: []Item{
+			{ShortDescription: "Mountain Dew 12PK", Price: "6.49"},
+		},
+	}
+}
+
+func TestLoadConfigAndBuildEngine(t *testing.T) {
+	cfg, err := LoadConfig("../rules.json")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	engine, err := cfg.BuildEngine()
+	if err != nil {
+		t.Fatalf("BuildEngine: %v", err)
+	}
+
+	points, explanation := engine.Explain(sampleReceipt())
+
+	// 6 (retailer) + 6 (odd day) + 5 (total > 10); description length (17) isn't a multiple of 3
+	const want = 17
+	if points != want {
+		t.Fatalf("expected %d points, got %d (breakdown: %+v)", want, points, explanation)
+	}
+
+	var sum int
+	for _, e := range explanation {
+		sum += e.Points
+	}
+	if sum != points {
+		t.Fatalf("explanation breakdown sums to %d, Score returned %d", sum, points)
+	}
+}
+
+func TestConfigValidateRejectsUnknownRuleType(t *testing.T) {
+	cfg := &Config{Rules: []RuleConfig{{Type: "not_a_real_rule", Enabled: true}}}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected Validate to reject an unknown rule type")
+	}
+}
+
+func TestConfigValidateRejectsEmptyRuleList(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected Validate to reject an empty rule list")
+	}
+}
+
+func TestBuildEngineRejectsZeroMultiple(t *testing.T) {
+	cfg := &Config{Rules: []RuleConfig{
+		{Type: "item_description_length_bonus", Enabled: true, Params: []byte(`{"Multiple":0,"PriceMultiplier":0.2}`)},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate should accept a known type regardless of params, got: %v", err)
+	}
+	if _, err := cfg.BuildEngine(); err == nil {
+		t.Fatal("expected BuildEngine to reject a zero Multiple instead of building a rule that panics on Apply")
+	}
+}
+
+func TestBuildEngineRejectsMalformedAfternoonWindow(t *testing.T) {
+	cfg := &Config{Rules: []RuleConfig{
+		{Type: "afternoon_window", Enabled: true, Params: []byte(`{"Start":"not-a-time","End":"16:00","Points":10}`)},
+	}}
+	if _, err := cfg.BuildEngine(); err == nil {
+		t.Fatal("expected BuildEngine to reject an unparsable time window")
+	}
+}
@@ -0,0 +1,33 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var (
+	retailerRegex = regexp.MustCompile(`^[\w\s\-&]+$`)
+	totalRegex    = regexp.MustCompile(`^\d+\.\d{2}$`)
+)
+
+// ValidateReceipt ensures that a receipt's structure is correct and contains
+// valid data, whether it came from the JSON API or an OCR extraction.
+func ValidateReceipt(receipt Receipt) error {
+	if receipt.Retailer == "" || receipt.PurchaseDate == "" || receipt.PurchaseTime == "" || receipt.Total == "" || len(receipt.Items) == 0 {
+		return fmt.Errorf("The receipt is invalid.") // Matches OpenAPI error response
+	}
+	if !retailerRegex.MatchString(receipt.Retailer) {
+		return fmt.Errorf("The receipt is invalid: retailer name format is incorrect.")
+	}
+	if _, err := time.Parse("2006-01-02", receipt.PurchaseDate); err != nil {
+		return fmt.Errorf("The receipt is invalid: purchaseDate format must be YYYY-MM-DD.")
+	}
+	if _, err := time.Parse("15:04", receipt.PurchaseTime); err != nil {
+		return fmt.Errorf("The receipt is invalid: purchaseTime format must be HH:MM (24-hour format).")
+	}
+	if !totalRegex.MatchString(receipt.Total) {
+		return fmt.Errorf("The receipt is invalid: total format must be a decimal with two places.")
+	}
+	return nil
+}
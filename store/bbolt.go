@@ -0,0 +1,187 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+var (
+	bucketReceipts         = []byte("receipts")
+	bucketHashIndex        = []byte("hash_index")
+	bucketIdempotencyIndex = []byte("idempotency_index")
+)
+
+// BoltStore persists receipts to a single BoltDB file, so data survives a
+// restart without needing an external database.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if needed) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("opening bbolt store at %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{bucketReceipts, bucketHashIndex, bucketIdempotencyIndex} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing bbolt buckets: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) Save(id string, r rules.Receipt, points int, explanation []rules.Explanation) (string, bool, error) {
+	hash := ContentHash(r)
+	var finalID string
+	var created bool
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		hashBucket := tx.Bucket(bucketHashIndex)
+		if existing := hashBucket.Get([]byte(hash)); existing != nil {
+			finalID = string(existing)
+			created = false
+			return nil
+		}
+
+		sr := StoredReceipt{
+			ID:          id,
+			Receipt:     r,
+			Points:      points,
+			Explanation: explanation,
+			SubmittedAt: time.Now(),
+			Status:      StatusOpen,
+			ContentHash: hash,
+		}
+		data, err := json.Marshal(sr)
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(bucketReceipts).Put([]byte(id), data); err != nil {
+			return err
+		}
+		if err := hashBucket.Put([]byte(hash), []byte(id)); err != nil {
+			return err
+		}
+		finalID = id
+		created = true
+		return nil
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return finalID, created, nil
+}
+
+func (s *BoltStore) GetPoints(id string) (int, bool, error) {
+	sr, ok, err := s.Get(id)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	return sr.Points, true, nil
+}
+
+func (s *BoltStore) Get(id string) (StoredReceipt, bool, error) {
+	var sr StoredReceipt
+	var found bool
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(bucketReceipts).Get([]byte(id))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &sr)
+	})
+	return sr, found, err
+}
+
+func (s *BoltStore) List(filter Filter, page Page) ([]StoredReceipt, int, error) {
+	var all []StoredReceipt
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketReceipts).ForEach(func(_, data []byte) error {
+			var sr StoredReceipt
+			if err := json.Unmarshal(data, &sr); err != nil {
+				return err
+			}
+			all = append(all, sr)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pageItems, total := applyFilterSortPage(all, filter, page)
+	return pageItems, total, nil
+}
+
+func (s *BoltStore) BulkUpdateStatus(ids []string, status, comment string) ([]string, []string, error) {
+	var updated, notFound []string
+
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bucketReceipts)
+		for _, id := range ids {
+			data := bucket.Get([]byte(id))
+			if data == nil {
+				notFound = append(notFound, id)
+				continue
+			}
+			var sr StoredReceipt
+			if err := json.Unmarshal(data, &sr); err != nil {
+				return err
+			}
+			sr.Status = status
+			sr.Comment = comment
+			updatedData, err := json.Marshal(sr)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put([]byte(id), updatedData); err != nil {
+				return err
+			}
+			updated = append(updated, id)
+		}
+		return nil
+	})
+	return updated, notFound, err
+}
+
+func (s *BoltStore) FindByIdempotencyKey(key string) (string, bool, error) {
+	var id string
+	var found bool
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(bucketIdempotencyIndex).Get([]byte(key))
+		if data != nil {
+			id = string(data)
+			found = true
+		}
+		return nil
+	})
+	return id, found, err
+}
+
+func (s *BoltStore) LinkIdempotencyKey(key, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketIdempotencyIndex).Put([]byte(key), []byte(id))
+	})
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
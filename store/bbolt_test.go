@@ -0,0 +1,73 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func newTestBoltStore(t *testing.T) *BoltStore {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "receipts.bolt")
+	s, err := NewBoltStore(dsn)
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestBoltStore_SaveDedupesByContent(t *testing.T) {
+	s := newTestBoltStore(t)
+
+	id1, created1, err := s.Save("id-1", sampleReceipt("Target", "10.00"), 42, nil)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if !created1 {
+		t.Fatal("expected first Save to create a new receipt")
+	}
+
+	id2, created2, err := s.Save("id-2", sampleReceipt("Target", "10.00"), 42, nil)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if created2 {
+		t.Fatal("expected second Save with identical content to not create a new receipt")
+	}
+	if id1 != id2 {
+		t.Fatalf("expected duplicate Save to return the original ID %q, got %q", id1, id2)
+	}
+}
+
+func TestBoltStore_ConcurrentDuplicatesCollapseToOne(t *testing.T) {
+	s := newTestBoltStore(t)
+
+	const n = 20
+	var wg sync.WaitGroup
+	ids := make([]string, n)
+	errs := make([]error, n)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, _, err := s.Save(fmt.Sprintf("id-%d", i), sampleReceipt("Target", "10.00"), 42, nil)
+			ids[i] = id
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Save[%d]: %v", i, err)
+		}
+		seen[ids[i]] = true
+	}
+	if len(seen) != 1 {
+		t.Fatalf("expected all concurrent duplicate submits to collapse to one receipt, got %d distinct IDs: %v", len(seen), seen)
+	}
+}
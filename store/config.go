@@ -0,0 +1,63 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// Backend names accepted by Config.Backend.
+const (
+	BackendMemory = "memory"
+	BackendBolt   = "bolt"
+	BackendSQLite = "sqlite"
+)
+
+// Config selects and configures a Store backend, plus the HTTP server
+// timeouts that protect it from slow clients.
+type Config struct {
+	// Backend is one of BackendMemory, BackendBolt, or BackendSQLite.
+	Backend string
+	// DSN is the backend-specific connection string: a file path for
+	// BackendBolt, or a database/sql DSN for BackendSQLite. Unused for
+	// BackendMemory.
+	DSN string
+	// ReadTimeout and WriteTimeout are applied to the http.Server so a slow
+	// or stalled client can't hold a connection (and the store's locks)
+	// open indefinitely.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// Validate checks that c describes a usable store configuration.
+func (c Config) Validate() error {
+	switch c.Backend {
+	case BackendMemory:
+	case BackendBolt, BackendSQLite:
+		if c.DSN == "" {
+			return fmt.Errorf("store: DSN is required for backend %q", c.Backend)
+		}
+	default:
+		return fmt.Errorf("store: unknown backend %q (expected %q, %q, or %q)", c.Backend, BackendMemory, BackendBolt, BackendSQLite)
+	}
+	if c.ReadTimeout <= 0 {
+		return fmt.Errorf("store: ReadTimeout must be positive")
+	}
+	if c.WriteTimeout <= 0 {
+		return fmt.Errorf("store: WriteTimeout must be positive")
+	}
+	return nil
+}
+
+// New builds the Store backend named by cfg.Backend. Call Validate first.
+func New(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case BackendMemory, "":
+		return NewMemoryStore(), nil
+	case BackendBolt:
+		return NewBoltStore(cfg.DSN)
+	case BackendSQLite:
+		return NewSQLiteStore(cfg.DSN)
+	default:
+		return nil, fmt.Errorf("store: unknown backend %q", cfg.Backend)
+	}
+}
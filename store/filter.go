@@ -0,0 +1,110 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// ContentHash computes a stable SHA-256 hash of the parts of a receipt that
+// determine its point value, so that resubmitting the same receipt can be
+// recognized regardless of incidental whitespace/case differences or item
+// ordering.
+func ContentHash(receipt rules.Receipt) string {
+	items := make([]rules.Item, len(receipt.Items))
+	copy(items, receipt.Items)
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ShortDescription != items[j].ShortDescription {
+			return items[i].ShortDescription < items[j].ShortDescription
+		}
+		return items[i].Price < items[j].Price
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "retailer=%s\n", strings.ToLower(strings.TrimSpace(receipt.Retailer)))
+	fmt.Fprintf(&b, "date=%s\n", receipt.PurchaseDate)
+	fmt.Fprintf(&b, "time=%s\n", receipt.PurchaseTime)
+	fmt.Fprintf(&b, "total=%s\n", receipt.Total)
+	for _, item := range items {
+		fmt.Fprintf(&b, "item=%s|%s\n", strings.ToLower(strings.TrimSpace(item.ShortDescription)), item.Price)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// matches reports whether sr satisfies every predicate set on f.
+func (f Filter) matches(sr StoredReceipt) bool {
+	if f.Retailer != "" && !strings.EqualFold(sr.Receipt.Retailer, f.Retailer) {
+		return false
+	}
+	total, _ := strconv.ParseFloat(sr.Receipt.Total, 64)
+	if f.MinTotal != nil && total < *f.MinTotal {
+		return false
+	}
+	if f.MaxTotal != nil && total > *f.MaxTotal {
+		return false
+	}
+	if f.PurchaseDateFrom != "" && sr.Receipt.PurchaseDate < f.PurchaseDateFrom {
+		return false
+	}
+	if f.PurchaseDateTo != "" && sr.Receipt.PurchaseDate > f.PurchaseDateTo {
+		return false
+	}
+	if f.MinPoints != nil && sr.Points < *f.MinPoints {
+		return false
+	}
+	if f.MaxPoints != nil && sr.Points > *f.MaxPoints {
+		return false
+	}
+	return true
+}
+
+// applyFilterSortPage is the shared List post-processing step used by every
+// Store implementation: filter the full set of receipts, sort it, then slice
+// out the requested page.
+func applyFilterSortPage(all []StoredReceipt, filter Filter, page Page) ([]StoredReceipt, int) {
+	filtered := make([]StoredReceipt, 0, len(all))
+	for _, sr := range all {
+		if filter.matches(sr) {
+			filtered = append(filtered, sr)
+		}
+	}
+
+	less := func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		switch page.OrderBy {
+		case "total":
+			aTotal, _ := strconv.ParseFloat(a.Receipt.Total, 64)
+			bTotal, _ := strconv.ParseFloat(b.Receipt.Total, 64)
+			return aTotal < bTotal
+		case "points":
+			return a.Points < b.Points
+		case "retailer":
+			return a.Receipt.Retailer < b.Receipt.Retailer
+		default: // "submittedAt"
+			return a.SubmittedAt.Before(b.SubmittedAt)
+		}
+	}
+	if page.SortDirection == "desc" {
+		sort.Slice(filtered, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.Slice(filtered, less)
+	}
+
+	total := len(filtered)
+	start := (page.Number - 1) * page.Size
+	if start < 0 || start >= total {
+		return []StoredReceipt{}, total
+	}
+	end := start + page.Size
+	if end > total {
+		end = total
+	}
+	return filtered[start:end], total
+}
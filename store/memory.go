@@ -0,0 +1,115 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// MemoryStore is the default Store backend: a mutex-guarded map that holds
+// everything in process memory. Data does not survive a restart.
+type MemoryStore struct {
+	mu               sync.RWMutex
+	receipts         map[string]StoredReceipt
+	byHash           map[string]string // content hash -> receipt ID
+	byIdempotencyKey map[string]string // Idempotency-Key -> receipt ID
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		receipts:         make(map[string]StoredReceipt),
+		byHash:           make(map[string]string),
+		byIdempotencyKey: make(map[string]string),
+	}
+}
+
+func (s *MemoryStore) Save(id string, r rules.Receipt, points int, explanation []rules.Explanation) (string, bool, error) {
+	hash := ContentHash(r)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existingID, ok := s.byHash[hash]; ok {
+		return existingID, false, nil
+	}
+
+	s.receipts[id] = StoredReceipt{
+		ID:          id,
+		Receipt:     r,
+		Points:      points,
+		Explanation: explanation,
+		SubmittedAt: time.Now(),
+		Status:      StatusOpen,
+		ContentHash: hash,
+	}
+	s.byHash[hash] = id
+	return id, true, nil
+}
+
+func (s *MemoryStore) GetPoints(id string) (int, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sr, ok := s.receipts[id]
+	if !ok {
+		return 0, false, nil
+	}
+	return sr.Points, true, nil
+}
+
+func (s *MemoryStore) Get(id string) (StoredReceipt, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sr, ok := s.receipts[id]
+	return sr, ok, nil
+}
+
+func (s *MemoryStore) List(filter Filter, page Page) ([]StoredReceipt, int, error) {
+	s.mu.RLock()
+	all := make([]StoredReceipt, 0, len(s.receipts))
+	for _, sr := range s.receipts {
+		all = append(all, sr)
+	}
+	s.mu.RUnlock()
+
+	pageItems, total := applyFilterSortPage(all, filter, page)
+	return pageItems, total, nil
+}
+
+func (s *MemoryStore) BulkUpdateStatus(ids []string, status, comment string) ([]string, []string, error) {
+	var updated, notFound []string
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		sr, ok := s.receipts[id]
+		if !ok {
+			notFound = append(notFound, id)
+			continue
+		}
+		sr.Status = status
+		sr.Comment = comment
+		s.receipts[id] = sr
+		updated = append(updated, id)
+	}
+	return updated, notFound, nil
+}
+
+func (s *MemoryStore) FindByIdempotencyKey(key string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	id, ok := s.byIdempotencyKey[key]
+	return id, ok, nil
+}
+
+func (s *MemoryStore) LinkIdempotencyKey(key, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byIdempotencyKey[key] = id
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
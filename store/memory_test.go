@@ -0,0 +1,101 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+func sampleReceipt(retailer, total string) rules.Receipt {
+	return rules.Receipt{
+		Retailer:     retailer,
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        total,
+		Items: []rules.Item{
+			{ShortDescription: "Widget", Price: "1.00"},
+		},
+	}
+}
+
+func TestMemoryStore_SaveDedupesByContent(t *testing.T) {
+	s := NewMemoryStore()
+
+	id1, created1, err := s.Save("id-1", sampleReceipt("Target", "10.00"), 42, nil)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if !created1 {
+		t.Fatal("expected first Save to create a new receipt")
+	}
+
+	id2, created2, err := s.Save("id-2", sampleReceipt("Target", "10.00"), 42, nil)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if created2 {
+		t.Fatal("expected second Save with identical content to not create a new receipt")
+	}
+	if id1 != id2 {
+		t.Fatalf("expected duplicate Save to return the original ID %q, got %q", id1, id2)
+	}
+}
+
+func TestMemoryStore_ListFiltersAndSorts(t *testing.T) {
+	s := NewMemoryStore()
+	s.Save("id-1", sampleReceipt("Target", "10.00"), 10, nil)
+	s.Save("id-2", sampleReceipt("Walmart", "20.00"), 30, nil)
+	s.Save("id-3", sampleReceipt("Target", "30.00"), 20, nil)
+
+	results, total, err := s.List(Filter{Retailer: "Target"}, Page{Number: 1, Size: 10, OrderBy: "points", SortDirection: "asc"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 2 {
+		t.Fatalf("expected 2 matching receipts, got %d", total)
+	}
+	if len(results) != 2 || results[0].Points != 10 || results[1].Points != 20 {
+		t.Fatalf("expected results sorted by points ascending, got %+v", results)
+	}
+}
+
+func TestMemoryStore_BulkUpdateStatus(t *testing.T) {
+	s := NewMemoryStore()
+	s.Save("id-1", sampleReceipt("Target", "10.00"), 10, nil)
+
+	updated, notFound, err := s.BulkUpdateStatus([]string{"id-1", "missing"}, StatusResolved, "looks good")
+	if err != nil {
+		t.Fatalf("BulkUpdateStatus: %v", err)
+	}
+	if len(updated) != 1 || updated[0] != "id-1" {
+		t.Fatalf("expected id-1 to be updated, got %+v", updated)
+	}
+	if len(notFound) != 1 || notFound[0] != "missing" {
+		t.Fatalf("expected missing to be reported not found, got %+v", notFound)
+	}
+
+	sr, ok, err := s.Get("id-1")
+	if err != nil || !ok {
+		t.Fatalf("Get: ok=%v, err=%v", ok, err)
+	}
+	if sr.Status != StatusResolved || sr.Comment != "looks good" {
+		t.Fatalf("expected status/comment to be updated, got %+v", sr)
+	}
+}
+
+func TestMemoryStore_IdempotencyKeyRoundTrip(t *testing.T) {
+	s := NewMemoryStore()
+	id, _, _ := s.Save("id-1", sampleReceipt("Target", "10.00"), 10, nil)
+
+	if err := s.LinkIdempotencyKey("key-1", id); err != nil {
+		t.Fatalf("LinkIdempotencyKey: %v", err)
+	}
+
+	got, ok, err := s.FindByIdempotencyKey("key-1")
+	if err != nil || !ok {
+		t.Fatalf("FindByIdempotencyKey: ok=%v, err=%v", ok, err)
+	}
+	if got != id {
+		t.Fatalf("expected %q, got %q", id, got)
+	}
+}
@@ -0,0 +1,221 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// SQLiteStore persists receipts to a SQLite database via database/sql, for
+// deployments that want a durable store without operating a separate
+// database server.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if needed) a SQLite database at dsn, e.g.
+// "file:receipts.db?_pragma=busy_timeout(5000)".
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite store at %s: %w", dsn, err)
+	}
+	// SQLite allows only one writer at a time; routing every query through a
+	// single *database/sql* connection serializes them in-process instead of
+	// letting concurrent connections fight over the file lock and surface
+	// spurious SQLITE_BUSY errors.
+	db.SetMaxOpenConns(1)
+
+	const schema = `
+	CREATE TABLE IF NOT EXISTS receipts (
+		id TEXT PRIMARY KEY,
+		receipt_json TEXT NOT NULL,
+		points INTEGER NOT NULL,
+		explanation_json TEXT NOT NULL DEFAULT '[]',
+		submitted_at DATETIME NOT NULL,
+		status TEXT NOT NULL,
+		comment TEXT NOT NULL DEFAULT '',
+		content_hash TEXT NOT NULL
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_receipts_content_hash ON receipts(content_hash);
+	CREATE TABLE IF NOT EXISTS idempotency_keys (
+		key TEXT PRIMARY KEY,
+		receipt_id TEXT NOT NULL
+	);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing sqlite schema: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Save relies on the unique index on content_hash to make duplicate
+// detection atomic: rather than a SELECT followed by a separate INSERT
+// (which races under concurrent submits of the same content, and can also
+// deadlock the single SQLite writer), it inserts optimistically and, if that
+// violates the unique constraint, looks up the ID the winning insert landed
+// on.
+func (s *SQLiteStore) Save(id string, r rules.Receipt, points int, explanation []rules.Explanation) (string, bool, error) {
+	hash := ContentHash(r)
+
+	receiptJSON, err := json.Marshal(r)
+	if err != nil {
+		return "", false, err
+	}
+	explanationJSON, err := json.Marshal(explanation)
+	if err != nil {
+		return "", false, err
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO receipts (id, receipt_json, points, explanation_json, submitted_at, status, comment, content_hash)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, string(receiptJSON), points, string(explanationJSON), time.Now(), StatusOpen, "", hash,
+	)
+	if err == nil {
+		return id, true, nil
+	}
+	if !isUniqueConstraintError(err) {
+		return "", false, err
+	}
+
+	var existingID string
+	if err := s.db.QueryRow(`SELECT id FROM receipts WHERE content_hash = ?`, hash).Scan(&existingID); err != nil {
+		return "", false, err
+	}
+	return existingID, false, nil
+}
+
+// isUniqueConstraintError reports whether err is a SQLite UNIQUE constraint
+// violation, as opposed to some other failure. modernc.org/sqlite doesn't
+// export its result-code constants, so matching the driver's own error text
+// is the straightforward way to distinguish this from other errors.
+func isUniqueConstraintError(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+func (s *SQLiteStore) scanReceipt(row interface {
+	Scan(dest ...any) error
+}) (StoredReceipt, bool, error) {
+	var sr StoredReceipt
+	var receiptJSON, explanationJSON string
+	err := row.Scan(&sr.ID, &receiptJSON, &sr.Points, &explanationJSON, &sr.SubmittedAt, &sr.Status, &sr.Comment, &sr.ContentHash)
+	if err == sql.ErrNoRows {
+		return StoredReceipt{}, false, nil
+	}
+	if err != nil {
+		return StoredReceipt{}, false, err
+	}
+	if err := json.Unmarshal([]byte(receiptJSON), &sr.Receipt); err != nil {
+		return StoredReceipt{}, false, err
+	}
+	if err := json.Unmarshal([]byte(explanationJSON), &sr.Explanation); err != nil {
+		return StoredReceipt{}, false, err
+	}
+	return sr, true, nil
+}
+
+func (s *SQLiteStore) GetPoints(id string) (int, bool, error) {
+	sr, ok, err := s.Get(id)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	return sr.Points, true, nil
+}
+
+func (s *SQLiteStore) Get(id string) (StoredReceipt, bool, error) {
+	row := s.db.QueryRow(
+		`SELECT id, receipt_json, points, explanation_json, submitted_at, status, comment, content_hash
+		 FROM receipts WHERE id = ?`, id)
+	return s.scanReceipt(row)
+}
+
+func (s *SQLiteStore) List(filter Filter, page Page) ([]StoredReceipt, int, error) {
+	rows, err := s.db.Query(
+		`SELECT id, receipt_json, points, explanation_json, submitted_at, status, comment, content_hash FROM receipts`)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var all []StoredReceipt
+	for rows.Next() {
+		sr, ok, err := s.scanReceipt(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			all = append(all, sr)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	pageItems, total := applyFilterSortPage(all, filter, page)
+	return pageItems, total, nil
+}
+
+func (s *SQLiteStore) BulkUpdateStatus(ids []string, status, comment string) ([]string, []string, error) {
+	var updated, notFound []string
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		result, err := tx.Exec(`UPDATE receipts SET status = ?, comment = ? WHERE id = ?`, status, comment, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, nil, err
+		}
+		if affected == 0 {
+			notFound = append(notFound, id)
+			continue
+		}
+		updated = append(updated, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return updated, notFound, nil
+}
+
+func (s *SQLiteStore) FindByIdempotencyKey(key string) (string, bool, error) {
+	var id string
+	err := s.db.QueryRow(`SELECT receipt_id FROM idempotency_keys WHERE key = ?`, key).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return id, true, nil
+}
+
+func (s *SQLiteStore) LinkIdempotencyKey(key, id string) error {
+	_, err := s.db.Exec(
+		`INSERT INTO idempotency_keys (key, receipt_id) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET receipt_id = excluded.receipt_id`,
+		key, id,
+	)
+	return err
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
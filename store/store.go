@@ -0,0 +1,89 @@
+// Package store defines the persistence layer for submitted receipts and
+// ships in-memory, BoltDB, and SQLite implementations so the backend can be
+// swapped via config without touching the HTTP layer.
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+)
+
+// Status values a StoredReceipt can be flagged with during triage.
+const (
+	StatusOpen           = "Open"
+	StatusResolved       = "Resolved"
+	StatusNeedsAttention = "NeedsAttention"
+)
+
+// ErrNotFound is returned by Get/GetPoints when no receipt exists for an ID.
+var ErrNotFound = errors.New("store: receipt not found")
+
+// StoredReceipt is the full record kept for a submitted receipt.
+type StoredReceipt struct {
+	ID          string              `json:"id"`
+	Receipt     rules.Receipt       `json:"receipt"`
+	Points      int                 `json:"points"`
+	Explanation []rules.Explanation `json:"explanation"`
+	SubmittedAt time.Time           `json:"submittedAt"`
+	Status      string              `json:"status"`
+	Comment     string              `json:"comment,omitempty"`
+	ContentHash string              `json:"-"`
+}
+
+// Filter holds the optional server-side filter predicates accepted by List.
+type Filter struct {
+	Retailer         string
+	MinTotal         *float64
+	MaxTotal         *float64
+	PurchaseDateFrom string
+	PurchaseDateTo   string
+	MinPoints        *int
+	MaxPoints        *int
+}
+
+// Page describes the pagination and sort parameters for List.
+type Page struct {
+	Number        int
+	Size          int
+	OrderBy       string
+	SortDirection string
+}
+
+// Store persists receipts and their computed points. Implementations must be
+// safe for concurrent use.
+type Store interface {
+	// Save atomically persists a receipt unless one with the same content
+	// already exists, in which case it returns that receipt's ID instead of
+	// creating a duplicate. created reports which case occurred. explanation
+	// is the per-rule breakdown that produced points, persisted alongside it
+	// so a later explain request replays the scoring that actually happened
+	// at submission time rather than whatever rules.json says now.
+	Save(id string, r rules.Receipt, points int, explanation []rules.Explanation) (finalID string, created bool, err error)
+
+	// GetPoints returns the points for a receipt ID.
+	GetPoints(id string) (points int, ok bool, err error)
+
+	// Get returns the full stored record for a receipt ID.
+	Get(id string) (StoredReceipt, bool, error)
+
+	// List returns the receipts matching filter, sorted and paginated per
+	// page, along with the total count of matches before pagination.
+	List(filter Filter, page Page) ([]StoredReceipt, int, error)
+
+	// BulkUpdateStatus flags every receipt in ids with status and comment,
+	// returning which IDs were updated and which were not found.
+	BulkUpdateStatus(ids []string, status, comment string) (updated, notFound []string, err error)
+
+	// FindByIdempotencyKey looks up the receipt ID previously linked to key.
+	FindByIdempotencyKey(key string) (id string, ok bool, err error)
+
+	// LinkIdempotencyKey associates key with a receipt ID so that replayed
+	// requests carrying the same Idempotency-Key header resolve to it.
+	LinkIdempotencyKey(key, id string) error
+
+	// Close releases any resources held by the store (open files, database
+	// connections, etc).
+	Close() error
+}
@@ -0,0 +1,33 @@
+package uploads
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config configures the upload pipeline: where raw files land on disk, how
+// many workers process the OCR queue, how deep that queue can get before
+// Submit blocks, and how long a single OCR extraction may run.
+type Config struct {
+	BlobDir     string
+	WorkerCount int
+	QueueDepth  int
+	OCRTimeout  time.Duration
+}
+
+// Validate checks that c describes a usable upload pipeline configuration.
+func (c Config) Validate() error {
+	if c.BlobDir == "" {
+		return fmt.Errorf("uploads: BlobDir must not be empty")
+	}
+	if c.WorkerCount <= 0 {
+		return fmt.Errorf("uploads: WorkerCount must be positive")
+	}
+	if c.QueueDepth <= 0 {
+		return fmt.Errorf("uploads: QueueDepth must be positive")
+	}
+	if c.OCRTimeout <= 0 {
+		return fmt.Errorf("uploads: OCRTimeout must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Package uploads implements the asynchronous pipeline behind
+// POST /receipts/upload: persisting the raw upload, queueing an OCR job, and
+// letting a worker pool turn the extracted text into a stored Receipt.
+package uploads
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nwc6624/receipt-processor-challenge/ocr"
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+	"github.com/nwc6624/receipt-processor-challenge/store"
+)
+
+// Status values a Job can be in.
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusDone       = "done"
+	StatusFailed     = "failed"
+)
+
+// Job tracks the lifecycle of one uploaded receipt image as it moves through
+// OCR extraction and scoring.
+type Job struct {
+	ID         string
+	Status     string
+	ReceiptID  string
+	Confidence float64
+	Err        string
+}
+
+// job is the work item enqueued for a worker: the job's bookkeeping record
+// plus the bytes it needs to process.
+type job struct {
+	id   string
+	data []byte
+	mime string
+}
+
+// Manager persists uploaded files to BlobDir, queues them for OCR, and
+// records each job's outcome so clients can poll GetJob.
+type Manager struct {
+	blobDir string
+	ocr     ocr.OCR
+	store   store.Store
+	engine  *rules.Engine
+	timeout time.Duration
+
+	queue chan job
+
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+// NewManager constructs a Manager. Call Start to launch its worker pool.
+func NewManager(cfg Config, backend ocr.OCR, receiptStore store.Store, engine *rules.Engine) (*Manager, error) {
+	if err := os.MkdirAll(cfg.BlobDir, 0o755); err != nil {
+		return nil, fmt.Errorf("uploads: creating blob dir %s: %w", cfg.BlobDir, err)
+	}
+	return &Manager{
+		blobDir: cfg.BlobDir,
+		ocr:     backend,
+		store:   receiptStore,
+		engine:  engine,
+		timeout: cfg.OCRTimeout,
+		queue:   make(chan job, cfg.QueueDepth),
+		jobs:    make(map[string]*Job),
+	}, nil
+}
+
+// Start launches workerCount goroutines that consume queued jobs until ctx
+// is canceled.
+func (m *Manager) Start(ctx context.Context, workerCount int) {
+	for i := 0; i < workerCount; i++ {
+		go m.worker(ctx)
+	}
+}
+
+// ErrQueueFull is returned by Submit when the job queue has no free slots,
+// so callers (the HTTP handler) can report backpressure instead of blocking
+// the request indefinitely.
+var ErrQueueFull = fmt.Errorf("uploads: job queue is full")
+
+// Submit persists data to the blob directory, enqueues an OCR job for it,
+// and returns the job's ID immediately. It returns ErrQueueFull rather than
+// blocking if every worker is busy and the queue has no free slots.
+func (m *Manager) Submit(data []byte, mime string) (string, error) {
+	jobID := uuid.New().String()
+
+	if err := m.persistBlob(jobID, mime, data); err != nil {
+		return "", err
+	}
+
+	// Register the job as pending before it's enqueued: a worker may dequeue
+	// and finish it the instant it hits the channel, and if that happened
+	// first, its Done/Failed record would be clobbered by the Pending entry
+	// written below.
+	m.mu.Lock()
+	m.jobs[jobID] = &Job{ID: jobID, Status: StatusPending}
+	m.mu.Unlock()
+
+	select {
+	case m.queue <- job{id: jobID, data: data, mime: mime}:
+	default:
+		m.mu.Lock()
+		delete(m.jobs, jobID)
+		m.mu.Unlock()
+		return "", ErrQueueFull
+	}
+
+	return jobID, nil
+}
+
+// GetJob returns the current state of a previously submitted job.
+func (m *Manager) GetJob(jobID string) (Job, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	j, ok := m.jobs[jobID]
+	if !ok {
+		return Job{}, false
+	}
+	return *j, true
+}
+
+// persistBlob writes the raw upload to disk under BlobDir, named by job ID.
+func (m *Manager) persistBlob(jobID, mime string, data []byte) error {
+	path := filepath.Join(m.blobDir, jobID+ocr.ExtensionForMIME(mime))
+	return os.WriteFile(path, data, 0o644)
+}
+
+// worker pulls jobs off the queue and runs them until ctx is canceled.
+func (m *Manager) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-m.queue:
+			m.process(ctx, j)
+		}
+	}
+}
+
+// process runs OCR extraction and scoring for one job, updating its status
+// as it goes.
+func (m *Manager) process(ctx context.Context, j job) {
+	m.setStatus(j.id, StatusProcessing, "")
+
+	opCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	receipt, confidence, err := m.ocr.Extract(opCtx, j.data, j.mime)
+	if err != nil {
+		m.setFailed(j.id, fmt.Errorf("ocr extraction failed: %w", err))
+		return
+	}
+
+	if err := rules.ValidateReceipt(receipt); err != nil {
+		m.setFailed(j.id, fmt.Errorf("extracted receipt is invalid: %w", err))
+		return
+	}
+
+	points, explanation := m.engine.Explain(receipt)
+	receiptID, _, err := m.store.Save(uuid.New().String(), receipt, points, explanation)
+	if err != nil {
+		m.setFailed(j.id, fmt.Errorf("failed to store receipt: %w", err))
+		return
+	}
+
+	m.mu.Lock()
+	m.jobs[j.id] = &Job{ID: j.id, Status: StatusDone, ReceiptID: receiptID, Confidence: confidence}
+	m.mu.Unlock()
+}
+
+func (m *Manager) setStatus(jobID, status, errMsg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if j, ok := m.jobs[jobID]; ok {
+		j.Status = status
+		j.Err = errMsg
+	}
+}
+
+func (m *Manager) setFailed(jobID string, err error) {
+	m.setStatus(jobID, StatusFailed, err.Error())
+}
+
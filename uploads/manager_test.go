@@ -0,0 +1,186 @@
+package uploads
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nwc6624/receipt-processor-challenge/rules"
+	"github.com/nwc6624/receipt-processor-challenge/store"
+)
+
+// fakeOCR returns a fixed receipt and confidence, or an error, without
+// touching any external binary or service.
+type fakeOCR struct {
+	receipt    rules.Receipt
+	confidence float64
+	err        error
+}
+
+func (f fakeOCR) Extract(ctx context.Context, data []byte, mime string) (rules.Receipt, float64, error) {
+	return f.receipt, f.confidence, f.err
+}
+
+func sampleReceipt() rules.Receipt {
+	return rules.Receipt{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Total:        "35.35",
+		Items: []rules.Item{
+			{ShortDescription: "Mountain Dew 12PK", Price: "6.49"},
+		},
+	}
+}
+
+func waitForStatus(t *testing.T, m *Manager, jobID string) Job {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		job, ok := m.GetJob(jobID)
+		if !ok {
+			t.Fatalf("job %s not found", jobID)
+		}
+		if job.Status == StatusDone || job.Status == StatusFailed {
+			return job
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for job %s to finish, last status %q", jobID, job.Status)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func TestManager_SubmitAndProcessSucceeds(t *testing.T) {
+	engine := rules.NewEngine(nil)
+	mgr, err := NewManager(Config{
+		BlobDir:     filepath.Join(t.TempDir(), "blobs"),
+		WorkerCount: 1,
+		QueueDepth:  4,
+		OCRTimeout:  time.Second,
+	}, fakeOCR{receipt: sampleReceipt(), confidence: 0.9}, store.NewMemoryStore(), engine)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr.Start(ctx, 1)
+
+	jobID, err := mgr.Submit([]byte("fake image bytes"), "image/jpeg")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	job := waitForStatus(t, mgr, jobID)
+	if job.Status != StatusDone {
+		t.Fatalf("expected job to finish as done, got %+v", job)
+	}
+	if job.ReceiptID == "" {
+		t.Fatal("expected a receipt ID to be set on success")
+	}
+}
+
+func TestManager_ProcessFailsOnOCRError(t *testing.T) {
+	engine := rules.NewEngine(nil)
+	mgr, err := NewManager(Config{
+		BlobDir:     filepath.Join(t.TempDir(), "blobs"),
+		WorkerCount: 1,
+		QueueDepth:  4,
+		OCRTimeout:  time.Second,
+	}, fakeOCR{err: fmt.Errorf("tesseract not found")}, store.NewMemoryStore(), engine)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr.Start(ctx, 1)
+
+	jobID, err := mgr.Submit([]byte("fake image bytes"), "image/jpeg")
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	job := waitForStatus(t, mgr, jobID)
+	if job.Status != StatusFailed {
+		t.Fatalf("expected job to fail, got %+v", job)
+	}
+	if job.Err == "" {
+		t.Fatal("expected an error message on a failed job")
+	}
+}
+
+func TestManager_SubmitReturnsErrQueueFullWhenNoWorkersAreDraining(t *testing.T) {
+	engine := rules.NewEngine(nil)
+	mgr, err := NewManager(Config{
+		BlobDir:     filepath.Join(t.TempDir(), "blobs"),
+		WorkerCount: 1,
+		QueueDepth:  1,
+		OCRTimeout:  time.Second,
+	}, fakeOCR{receipt: sampleReceipt(), confidence: 0.9}, store.NewMemoryStore(), engine)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	// Deliberately not started: no worker drains the queue, so the second
+	// Submit must see it full instead of blocking forever.
+
+	if _, err := mgr.Submit([]byte("a"), "image/jpeg"); err != nil {
+		t.Fatalf("first Submit: %v", err)
+	}
+	if _, err := mgr.Submit([]byte("b"), "image/jpeg"); err != ErrQueueFull {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+}
+
+// TestManager_SubmitDoesNotRaceWorkerCompletion guards against a job being
+// registered as Pending *after* a fast worker has already dequeued, finished,
+// and recorded it as Done/Failed, which would otherwise clobber the final
+// status back to Pending forever. Submitting concurrently (rather than in a
+// sequential loop) is what actually exposes the race: many Submit calls
+// racing many workers at once reproduces it reliably, where one at a time
+// almost never does.
+func TestManager_SubmitDoesNotRaceWorkerCompletion(t *testing.T) {
+	engine := rules.NewEngine(nil)
+	const n = 2000
+	mgr, err := NewManager(Config{
+		BlobDir:     filepath.Join(t.TempDir(), "blobs"),
+		WorkerCount: 4,
+		QueueDepth:  n,
+		OCRTimeout:  time.Second,
+	}, fakeOCR{receipt: sampleReceipt(), confidence: 0.9}, store.NewMemoryStore(), engine)
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr.Start(ctx, 4)
+
+	jobIDs := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			jobID, err := mgr.Submit([]byte("fake image bytes"), "image/jpeg")
+			if err != nil {
+				t.Errorf("Submit[%d]: %v", i, err)
+				return
+			}
+			jobIDs[i] = jobID
+		}(i)
+	}
+	wg.Wait()
+
+	for _, jobID := range jobIDs {
+		job := waitForStatus(t, mgr, jobID)
+		if job.Status != StatusDone {
+			t.Fatalf("expected job %s to finish as done, got %+v", jobID, job)
+		}
+	}
+}